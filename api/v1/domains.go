@@ -2,37 +2,375 @@ package v1
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
 
 	pb "api.vimana.host/v1/domains"
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// Header carrying the mTLS-verified identity of the caller, set by Ztunnel before the
+// request reaches this service.
+const callerIdentityHeader = "x-vimana-caller"
+
+const (
+	listenerAcceptedPollInterval = 500 * time.Millisecond
+	listenerAcceptedTimeout      = 30 * time.Second
 )
 
 func (s *ApiService) Create(ctx context.Context, request *pb.CreateDomainRequest) (*pb.CreateDomainResponse, error) {
-	networking_rest := s.k8s.NetworkingV1().RESTClient()
-	gateway, err := networking_rest.Get().Namespace(s.namespace).Do(ctx).Get()
-	s.Logger.Info(fmt.Sprintf("Tried: %v, %v", gateway, err))
-	return nil, nil
+	caller, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	hostnames := append([]string{request.Name}, request.Aliases...)
+	for _, hostname := range hostnames {
+		if err := validateHostname(hostname); err != nil {
+			return nil, err
+		}
+	}
+
+	vimana, err := s.soleVimana(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Uniqueness is checked against the would-be canonical hostname for request.Name, which
+	// becomes this Domain's Id below, plus every alias.
+	if err = s.ensureHostnamesUnique(ctx, append([]string{canonicalDomain(request.Name)}, request.Aliases...), ""); err != nil {
+		return nil, err
+	}
+
+	objectName, err := generateObjectName()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate domain object name: %v", err)
+	}
+
+	domain := &apiv1alpha1.Domain{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objectName,
+			Namespace: s.namespace,
+		},
+		Spec: apiv1alpha1.DomainSpec{
+			Id:      request.Name,
+			Vimana:  vimana.Name,
+			Aliases: request.Aliases,
+			Owners:  withOwner(request.Owners, caller),
+		},
+	}
+	if err = s.k8s.Create(ctx, domain); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create domain: %v", err)
+	}
+
+	if err = s.waitForListenerAccepted(ctx, vimana.Name, request.Name); err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateDomainResponse{Domain: toProtoDomain(domain)}, nil
 }
 
 func (s *ApiService) List(ctx context.Context, request *pb.ListDomainsRequest) (*pb.ListDomainsResponse, error) {
-	return nil, nil
+	caller, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := []client.ListOption{client.InNamespace(s.namespace)}
+	if request.PageSize > 0 {
+		listOpts = append(listOpts, client.Limit(int64(request.PageSize)))
+	}
+	if request.PageToken != "" {
+		listOpts = append(listOpts, client.Continue(request.PageToken))
+	}
+
+	domains := &apiv1alpha1.DomainList{}
+	if err = s.k8s.List(ctx, domains, listOpts...); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list domains: %v", err)
+	}
+
+	response := &pb.ListDomainsResponse{NextPageToken: domains.Continue}
+	for i := range domains.Items {
+		domain := &domains.Items[i]
+		if ownsDomain(domain, caller) {
+			response.Domains = append(response.Domains, toProtoDomain(domain))
+		}
+	}
+	return response, nil
 }
 
 func (s *ApiService) Get(ctx context.Context, request *pb.GetDomainRequest) (*pb.Domain, error) {
-	// Currently just echoes the name for testing.
-	return &pb.Domain{
-		Name: request.Name,
-	}, nil
+	caller, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := s.getOwnedDomain(ctx, request.Name, caller)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoDomain(domain), nil
 }
 
 func (s *ApiService) UpdateAliases(ctx context.Context, request *pb.UpdateDomainAliasesRequest) (*pb.Domain, error) {
-	return nil, nil
+	caller, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := s.getOwnedDomain(ctx, request.Name, caller)
+	if err != nil {
+		return nil, err
+	}
+	if request.ResourceVersion != "" && request.ResourceVersion != domain.ResourceVersion {
+		return nil, status.Error(codes.Aborted, "domain was concurrently modified, please retry with the latest resource version")
+	}
+	for _, alias := range request.Aliases {
+		if err = validateHostname(alias); err != nil {
+			return nil, err
+		}
+	}
+	if err = s.ensureHostnamesUnique(ctx, request.Aliases, domain.Spec.Id); err != nil {
+		return nil, err
+	}
+
+	patch := client.MergeFrom(domain.DeepCopy())
+	domain.Spec.Aliases = request.Aliases
+	if err = s.k8s.Patch(ctx, domain, patch); err != nil {
+		return nil, patchError(err, "aliases")
+	}
+	return toProtoDomain(domain), nil
 }
 
 func (s *ApiService) UpdateOwners(ctx context.Context, request *pb.UpdateDomainOwnersRequest) (*pb.Domain, error) {
-	return nil, nil
+	caller, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := s.getOwnedDomain(ctx, request.Name, caller)
+	if err != nil {
+		return nil, err
+	}
+	if request.ResourceVersion != "" && request.ResourceVersion != domain.ResourceVersion {
+		return nil, status.Error(codes.Aborted, "domain was concurrently modified, please retry with the latest resource version")
+	}
+	if len(request.Owners) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a domain must always have at least 1 owner")
+	}
+
+	patch := client.MergeFrom(domain.DeepCopy())
+	domain.Spec.Owners = request.Owners
+	if err = s.k8s.Patch(ctx, domain, patch); err != nil {
+		return nil, patchError(err, "owners")
+	}
+	return toProtoDomain(domain), nil
 }
 
 func (s *ApiService) Delete(ctx context.Context, request *pb.DeleteDomainRequest) (*pb.DeleteDomainResponse, error) {
-	return nil, nil
+	caller, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := s.getOwnedDomain(ctx, request.Name, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleting the Domain is enough: the Vimana controller watches Domains and
+	// re-renders the Gateway without the corresponding listener.
+	if err = s.k8s.Delete(ctx, domain); err != nil && !apierrors.IsNotFound(err) {
+		return nil, status.Errorf(codes.Internal, "failed to delete domain %q: %v", request.Name, err)
+	}
+	return &pb.DeleteDomainResponse{}, nil
+}
+
+// callerIdentity extracts the mTLS-verified identity of the caller from incoming request
+// metadata. Every Domains API method requires one, since every domain is owned.
+func callerIdentity(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		if values := md.Get(callerIdentityHeader); len(values) > 0 && values[0] != "" {
+			return values[0], nil
+		}
+	}
+	return "", status.Error(codes.Unauthenticated, "missing caller identity")
+}
+
+// ownsDomain reports whether caller is listed as an owner of domain.
+func ownsDomain(domain *apiv1alpha1.Domain, caller string) bool {
+	for _, owner := range domain.Spec.Owners {
+		if owner == caller {
+			return true
+		}
+	}
+	return false
+}
+
+// getOwnedDomain fetches the Domain whose Spec.Id is id, in the service's namespace,
+// returning NotFound both when it does not exist and when caller does not own it,
+// so as not to leak the existence of domains the caller cannot see. Id, not the Domain's
+// (system-generated) object name, is what callers of the Domains API address a domain by.
+func (s *ApiService) getOwnedDomain(ctx context.Context, id, caller string) (*apiv1alpha1.Domain, error) {
+	domains := &apiv1alpha1.DomainList{}
+	if err := s.k8s.List(ctx, domains, client.InNamespace(s.namespace)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list domains: %v", err)
+	}
+	for i := range domains.Items {
+		if domains.Items[i].Spec.Id == id {
+			domain := &domains.Items[i]
+			if !ownsDomain(domain, caller) {
+				return nil, status.Errorf(codes.NotFound, "domain %q not found", id)
+			}
+			return domain, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "domain %q not found", id)
+}
+
+// soleVimana returns the single Vimana resource expected to exist in the service's namespace.
+func (s *ApiService) soleVimana(ctx context.Context) (*apiv1alpha1.Vimana, error) {
+	vimanas := &apiv1alpha1.VimanaList{}
+	if err := s.k8s.List(ctx, vimanas, client.InNamespace(s.namespace)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list vimanas: %v", err)
+	}
+	if len(vimanas.Items) != 1 {
+		return nil, status.Errorf(codes.FailedPrecondition, "expected exactly 1 Vimana in namespace %q, found %d", s.namespace, len(vimanas.Items))
+	}
+	return &vimanas.Items[0], nil
+}
+
+// ensureHostnamesUnique returns an error if any of hostnames is already claimed, as a
+// canonical domain or an alias, by some other Domain anywhere in the cluster.
+// excludeId exempts the domain currently being updated from the check.
+func (s *ApiService) ensureHostnamesUnique(ctx context.Context, hostnames []string, excludeId string) error {
+	domains := &apiv1alpha1.DomainList{}
+	if err := s.k8s.List(ctx, domains); err != nil {
+		return status.Errorf(codes.Internal, "failed to list domains: %v", err)
+	}
+
+	taken := make(map[string]string, len(domains.Items))
+	for _, domain := range domains.Items {
+		if domain.Spec.Id == excludeId {
+			continue
+		}
+		taken[canonicalDomain(domain.Spec.Id)] = domain.Spec.Id
+		for _, alias := range domain.Spec.Aliases {
+			taken[alias] = domain.Spec.Id
+		}
+	}
+	for _, hostname := range hostnames {
+		if owner, ok := taken[hostname]; ok {
+			return status.Errorf(codes.AlreadyExists, "hostname %q is already in use by domain %q", hostname, owner)
+		}
+	}
+	return nil
+}
+
+// waitForListenerAccepted blocks until the Gateway listener backing domainId reports
+// Accepted=true, or until listenerAcceptedTimeout elapses.
+func (s *ApiService) waitForListenerAccepted(ctx context.Context, vimanaName, domainId string) error {
+	namespacedName := types.NamespacedName{Name: gatewayName(vimanaName), Namespace: s.namespace}
+	sectionName := listenerSectionName(canonicalDomain(domainId))
+
+	deadline := time.Now().Add(listenerAcceptedTimeout)
+	for {
+		gateway := &gwapi.Gateway{}
+		err := s.k8s.Get(ctx, namespacedName, gateway)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return status.Errorf(codes.Internal, "failed to get gateway %q: %v", namespacedName.Name, err)
+		}
+		for _, listenerStatus := range gateway.Status.Listeners {
+			if listenerStatus.Name != sectionName {
+				continue
+			}
+			for _, condition := range listenerStatus.Conditions {
+				if condition.Type == string(gwapi.ListenerConditionAccepted) && condition.Status == metav1.ConditionTrue {
+					return nil
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return status.Error(codes.DeadlineExceeded, "timed out waiting for the domain's gateway listener to be accepted")
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(listenerAcceptedPollInterval):
+		}
+	}
+}
+
+func patchError(err error, field string) error {
+	if apierrors.IsConflict(err) {
+		return status.Error(codes.Aborted, "domain was concurrently modified, please retry")
+	}
+	return status.Errorf(codes.Internal, "failed to update domain %s: %v", field, err)
+}
+
+func toProtoDomain(domain *apiv1alpha1.Domain) *pb.Domain {
+	return &pb.Domain{
+		Name:            domain.Spec.Id,
+		Aliases:         domain.Spec.Aliases,
+		Owners:          domain.Spec.Owners,
+		ResourceVersion: domain.ResourceVersion,
+	}
+}
+
+func validateHostname(hostname string) error {
+	if errs := utilvalidation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument, "%q is not a valid hostname: %s", hostname, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func withOwner(owners []string, caller string) []string {
+	for _, owner := range owners {
+		if owner == caller {
+			return owners
+		}
+	}
+	return append([]string{caller}, owners...)
+}
+
+// generateObjectName mints a random suffix for a Domain's Kubernetes object name, which (unlike
+// Spec.Id) has no user-facing meaning and only needs to be unique.
+func generateObjectName() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// The canonical domain name and Gateway naming scheme below must stay in sync with
+// the corresponding unexported helpers in operator/internal/controller.
+
+func canonicalDomain(domainId string) string {
+	return fmt.Sprintf("%s.app.vimana.host", domainId)
+}
+
+func gatewayName(vimanaName string) string {
+	return vimanaName + ".gateway"
+}
+
+func listenerSectionName(hostname string) gwapi.SectionName {
+	hash := sha256.Sum256([]byte(hostname))
+	return gwapi.SectionName(fmt.Sprintf("l-%s", hex.EncodeToString(hash[:])))
 }
@@ -12,11 +12,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
 
 	v1 "api.vimana.host/v1"
 	"api.vimana.host/v1/domains"
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
 )
 
 // Always listen on TCP port 80.
@@ -36,9 +39,19 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to configure the in-cluster K8s client: %v\n", err)
 	}
-	client, err := kubernetes.NewForConfig(config)
+
+	// The API server reads and writes Vimana/Domain CRDs and Gateway API objects directly,
+	// so it needs a scheme-aware client rather than the bare clientset.
+	scheme := runtime.NewScheme()
+	if err = apiv1alpha1.AddToScheme(scheme); err != nil {
+		log.Fatalf("Failed to register the Vimana scheme: %v\n", err)
+	}
+	if err = gwapi.Install(scheme); err != nil {
+		log.Fatalf("Failed to register the Gateway API scheme: %v\n", err)
+	}
+	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
 	if err != nil {
-		log.Fatalf("Failed to create K8s client set: %v\n", err)
+		log.Fatalf("Failed to create K8s client: %v\n", err)
 	}
 
 	// Structured logger used in actions.
@@ -53,7 +66,7 @@ func main() {
 		log.Fatalf("Expected the K8s namespace to be explicitly provided.", err)
 	}
 
-	service := v1.NewApiService(client, namespace, logger)
+	service := v1.NewApiService(k8sClient, namespace, logger)
 	server := grpc.NewServer(grpc.UnaryInterceptor(loggingInterceptor))
 	domains.RegisterDomainsServer(server, service)
 	reflection.Register(server)
@@ -3,15 +3,15 @@ package v1
 import (
 	"go.uber.org/zap"
 
-	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type ApiService struct {
-	k8s       *kubernetes.Clientset
+	k8s       client.Client
 	namespace string
 	Logger    *zap.Logger
 }
 
-func NewApiService(k8s *kubernetes.Clientset, namespace string, logger *zap.Logger) *ApiService {
+func NewApiService(k8s client.Client, namespace string, logger *zap.Logger) *ApiService {
 	return &ApiService{k8s, namespace, logger}
 }
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -12,12 +13,21 @@ type DomainSpec struct {
 	// Important: Run `bazel run //operator:generate` to regenerate code
 	//   after modifying this file.
 
-	// Auto-generated unique ID of the domain, as a hex-encoded string.
+	// The domain's primary hostname, as requested by its creator (e.g. "api.example.com").
+	// Globally unique across the cluster; see the Domains API's CreateDomainRequest.Name.
 	Id string `json:"id"`
 
+	// ID of the Vimana cluster that owns this domain.
+	// The domain's Gateway listeners are attached to that Vimana's Gateway.
+	Vimana string `json:"vimana"`
+
 	// List of alias domain names.
 	Aliases []string `json:"aliases,omitempty"`
 
+	// Identities (e.g. caller principals) permitted to manage this domain
+	// through the Domains API. The creator of a Domain is always implicitly an owner.
+	Owners []string `json:"owners,omitempty"`
+
 	// Subset of regions in which servers within this domain may run.
 	// If empty, they could run anywhere globally.
 	Regions []string `json:"regions,omitempty"`
@@ -28,9 +38,21 @@ type DomainSpec struct {
 	// gRPC-specific configuration for the domain.
 	Grpc DomainGrpc `json:"grpc,omitempty"`
 
+	// Default retry budget for requests to any server under this domain, absent a more specific
+	// override on Server.Spec.Retry or the matching TrafficPolicy.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Default request timeouts for any server under this domain, absent a more specific override
+	// on Server.Spec.Timeout or the matching TrafficPolicy.
+	Timeout *TimeoutPolicy `json:"timeout,omitempty"`
+
 	// Provide an auto-generated OpenAPI Description at `/.well-known/schema.json`
 	// covering all the HTTP-transcoded methods of all the servers in the domain.
 	OpenApi bool `json:"openApi,omitempty"`
+
+	// Overrides the owning Vimana's CertificateIssuer for this domain (and its aliases).
+	// If left unset, the Vimana's CertificateIssuer is used instead.
+	CertificateIssuer *cmmeta.ObjectReference `json:"certificateIssuer,omitempty"`
 }
 
 // DomainGrpc defines the desired state of the gRPC settings of a Domain.
@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// RateLimitKey identifies what a RateLimitPolicy's RequestsPerSecond budget is keyed by.
+type RateLimitKey string
+
+const (
+	// RateLimitKeyClientIp limits requests per client IP address.
+	RateLimitKeyClientIp RateLimitKey = "ClientIp"
+	// RateLimitKeyPrincipal limits requests per authenticated principal (see AuthPolicy.Jwt).
+	RateLimitKeyPrincipal RateLimitKey = "Principal"
+)
+
+// RateLimitPolicy caps the rate of requests admitted to a domain.
+type RateLimitPolicy struct {
+	// Requests per second permitted per Key.
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// What the rate limit budget is keyed by. Defaults to RateLimitKeyClientIp.
+	Key RateLimitKey `json:"key,omitempty"`
+}
+
+// JwtAuth requires callers to present a JWT issued by Issuer, verified against Jwks.
+type JwtAuth struct {
+	// Expected `iss` claim of the presented JWT.
+	Issuer string `json:"issuer"`
+
+	// URL of the JWKS endpoint used to verify the JWT's signature.
+	JwksUrl string `json:"jwksUrl"`
+}
+
+// MtlsAuth requires callers to present a client certificate signed by a CA in ClientCaSecretRef.
+type MtlsAuth struct {
+	// Secret, in the same namespace as the DomainPolicy, holding the trusted CA bundle under
+	// its "ca.crt" key.
+	ClientCaSecretRef corev1.LocalObjectReference `json:"clientCaSecretRef"`
+}
+
+// AuthPolicy requires callers to authenticate before their request reaches a backend.
+// Exactly 1 of Jwt or Mtls should be set.
+type AuthPolicy struct {
+	Jwt  *JwtAuth  `json:"jwt,omitempty"`
+	Mtls *MtlsAuth `json:"mtls,omitempty"`
+}
+
+// DomainPolicySpec defines the desired state of a DomainPolicy.
+type DomainPolicySpec struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// The Domain this policy attaches to, following the Gateway API policy-attachment
+	// convention. Must name a Domain in the same namespace as this DomainPolicy.
+	TargetRef gwapiv1alpha2.LocalPolicyTargetReference `json:"targetRef"`
+
+	// Caps the rate of requests admitted to the target Domain. If left unset, no rate limit
+	// is enforced.
+	RateLimit *RateLimitPolicy `json:"rateLimit,omitempty"`
+
+	// Requires callers to authenticate before their request reaches a backend. If left unset,
+	// no authentication is required.
+	Auth *AuthPolicy `json:"auth,omitempty"`
+}
+
+// DomainPolicyStatus defines the observed state of a DomainPolicy.
+type DomainPolicyStatus struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// Status conditions of the DomainPolicy instance, including "Enforced" once its
+	// Envoy Gateway resources have been created for the target Domain's listeners.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// DomainPolicy is the Schema for the domainpolicies API.
+type DomainPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DomainPolicySpec   `json:"spec,omitempty"`
+	Status DomainPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DomainPolicyList contains a list of DomainPolicy.
+type DomainPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DomainPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DomainPolicy{}, &DomainPolicyList{})
+}
+
+// Return a pointer to the slice of conditions for this resource.
+func (resource *DomainPolicy) GetConditions() *[]metav1.Condition {
+	return &resource.Status.Conditions
+}
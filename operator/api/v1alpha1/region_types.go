@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// RegionSpec defines the desired state of a Region.
+type RegionSpec struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// Cloud provider and failure domain this Region corresponds to, e.g. "aws/us-east-1" or
+	// "gcp/us-west1". Matched verbatim against the entries of DomainSpec.Regions.
+	FailureDomain string `json:"failureDomain"`
+
+	// Whether this Region is currently able to serve traffic. Set by whatever external
+	// health-checking process owns this Region; the operator itself never flips this field,
+	// only reads it.
+	Healthy bool `json:"healthy,omitempty"`
+}
+
+// RegionStatus defines the observed state of a Region.
+type RegionStatus struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// Status conditions of the Region instance.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Region is the Schema for the regions API. Regions are namespace-scoped like every other
+// Vimana resource; see globalRegionsEnabled in the controller package for how Regions shared
+// across namespaces are resolved.
+type Region struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegionSpec   `json:"spec,omitempty"`
+	Status RegionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RegionList contains a list of Region.
+type RegionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Region `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Region{}, &RegionList{})
+}
+
+// Return a pointer to the slice of conditions for this resource.
+func (resource *Region) GetConditions() *[]metav1.Condition {
+	return &resource.Status.Conditions
+}
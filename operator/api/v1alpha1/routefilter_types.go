@@ -0,0 +1,115 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// RouteRetryFilterSpec mirrors a RetryPolicy as a standalone CR, following the pattern of
+// Consul API Gateway's RouteRetryFilter: a GRPCRoute rule's Filters can target it via an
+// ExtensionRef, giving gateway implementations without a native Gateway API retry filter a
+// concrete resource to key their retry behavior off of. DomainReconciler generates and owns
+// these; they're never user-authored.
+type RouteRetryFilterSpec struct {
+	// Maximum number of attempts, including the original request.
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Timeout applied to each individual attempt.
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+
+	// gRPC status codes that should be retried, e.g. "UNAVAILABLE", "DEADLINE_EXCEEDED".
+	RetryOn []string `json:"retryOn,omitempty"`
+
+	// Initial delay before the first retry.
+	BackoffBase *metav1.Duration `json:"backoffBase,omitempty"`
+
+	// Upper bound the delay is capped at as attempts increase.
+	BackoffMax *metav1.Duration `json:"backoffMax,omitempty"`
+}
+
+// RouteRetryFilterStatus defines the observed state of a RouteRetryFilter.
+type RouteRetryFilterStatus struct {
+	// Status conditions of the RouteRetryFilter instance.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// RouteRetryFilter is the Schema for the routeretryfilters API. It's always generated and owned
+// by a Domain; see DomainReconciler.reconcileRouteRetryFilter.
+type RouteRetryFilter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteRetryFilterSpec   `json:"spec,omitempty"`
+	Status RouteRetryFilterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteRetryFilterList contains a list of RouteRetryFilter.
+type RouteRetryFilterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteRetryFilter `json:"items"`
+}
+
+// RouteTimeoutFilterSpec mirrors a TimeoutPolicy as a standalone CR, following the pattern of
+// Consul API Gateway's RouteTimeoutFilter, for gateway implementations that don't honor Gateway
+// API's native rules[].timeouts. DomainReconciler generates and owns these; they're never
+// user-authored.
+type RouteTimeoutFilterSpec struct {
+	// How long a matching request may run end-to-end before the gateway cancels it.
+	Request *metav1.Duration `json:"request,omitempty"`
+
+	// How long a single backend attempt may run before it's considered failed, distinct from
+	// Request when a RouteRetryFilter allows more than 1 attempt.
+	BackendRequest *metav1.Duration `json:"backendRequest,omitempty"`
+}
+
+// RouteTimeoutFilterStatus defines the observed state of a RouteTimeoutFilter.
+type RouteTimeoutFilterStatus struct {
+	// Status conditions of the RouteTimeoutFilter instance.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// RouteTimeoutFilter is the Schema for the routetimeoutfilters API. It's always generated and
+// owned by a Domain; see DomainReconciler.reconcileRouteTimeoutFilter.
+type RouteTimeoutFilter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteTimeoutFilterSpec   `json:"spec,omitempty"`
+	Status RouteTimeoutFilterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteTimeoutFilterList contains a list of RouteTimeoutFilter.
+type RouteTimeoutFilterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteTimeoutFilter `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteRetryFilter{}, &RouteRetryFilterList{})
+	SchemeBuilder.Register(&RouteTimeoutFilter{}, &RouteTimeoutFilterList{})
+}
+
+// Return a pointer to the slice of conditions for this resource.
+func (resource *RouteRetryFilter) GetConditions() *[]metav1.Condition {
+	return &resource.Status.Conditions
+}
+
+// Return a pointer to the slice of conditions for this resource.
+func (resource *RouteTimeoutFilter) GetConditions() *[]metav1.Condition {
+	return &resource.Status.Conditions
+}
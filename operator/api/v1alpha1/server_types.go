@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,9 +34,32 @@ type ServerSpec struct {
 	// Map from feature flag names to configurations.
 	Features map[string]FeatureFlag `json:"features,omitempty"`
 
+	// Default retry budget for requests to this server, absent a more specific override on the
+	// TrafficPolicy matching the request. Overrides Domain.Spec.Retry.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Default request timeouts for this server, absent a more specific override on the
+	// TrafficPolicy matching the request. Overrides Domain.Spec.Timeout.
+	Timeout *TimeoutPolicy `json:"timeout,omitempty"`
+
 	// Map from version strings to traffic weights.
 	// The traffic proportion is the weight divided by the total of all weights.
 	VersionWeights map[string]int32 `json:"versionWeights,omitempty"`
+
+	// Location of this server's OpenAPI 3 document describing its REST-transcoded surface. Only
+	// consulted when the owning Domain has Spec.OpenApi set, in which case it's used to derive
+	// the Domain's HTTPRoute; see DomainReconciler.reconcileHttpRoute.
+	OpenApiSchemaRef *OpenApiSchemaRef `json:"openApiSchemaRef,omitempty"`
+}
+
+// OpenApiSchemaRef points at an OpenAPI 3 document. Exactly 1 of ConfigMapKeyRef or Url must be
+// set.
+type OpenApiSchemaRef struct {
+	// A key within a ConfigMap in the Server's own namespace.
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// A URL the document is fetched from directly.
+	Url *string `json:"url,omitempty"`
 }
 
 type ServerAuth struct {
@@ -43,31 +67,75 @@ type ServerAuth struct {
 	Jwks []string `json:"jwks,omitempty"`
 }
 
+// FeatureFlag is a case-based flag: Cases is evaluated in order, and the value of the first
+// case whose Conditions match is what the flag evaluates to for a given request. The final
+// case must have no Conditions (enforced by the Server validating webhook) and so always
+// matches, acting as the flag's default. See pkg/flags for the evaluator that walks these.
+//
+//	"some-bool-flag":
+//	  cases:
+//	    - boolean: true
+//	      conditions:
+//	        # At least one top-level condition must match (they are "OR-joined").
+//	        # This condition matches people with a verified email address on 'example.com'
+//	        # according to any JWT attached to the request.
+//	        # This kind of filter is only useful if you specify JWKS to verify JWTs.
+//	        - all:
+//	            - hasEmail: "*@example.com"
+//	        # Conditions can be nested 1 level deep.
+//	        # At this level, they are "AND-joined";
+//	        # all must match for the overall (top-level) condition to match.
+//	        # This condition matches people from 'sometimes.com', but only half the time.
+//	        - all:
+//	            - hasEmail: "*@sometimes.com"
+//	            - random: "50%"
+//	    # Each case must use the same value type as the others.
+//	    # The final case must have no conditions.
+//	    - boolean: false
+//	"some-string-flag":
+//	  cases:
+//	    - string: "good"
+//	      conditions:
+//	        ...
 type FeatureFlag struct {
-	// TODO: Define feature flags.
-	//   "some-bool-flag":
-	//     # Each case is defined by a value and a set of conditions.
-	//     # Evaluate the case in order and use the value of the first one whose conditions match.
-	//     - boolean: true
-	//       conditions:
-	//         # At least one top-level condition must match (they are "OR-joined").
-	//         # This condition matches people with a verified email address on 'example.com'
-	//         # according to any attached JWT attached to the request.
-	//         # This kind of filter is only useful if you specify JWKS to verify JWTs.
-	//         - hasEmail: "*@example.com"
-	//           # Conditions can be nested 1 level deep.
-	//           # At this level, they are "AND-joined";
-	//           # all must match for the overall (top-level) condition to match.
-	//           # This condition matches people from 'sometimes.com', but only half the time.
-	//         - - hasEmail: "*@sometimes.com"
-	//           - random: 50%
-	//     # Each case must use the same type.
-	//     # The final case must have no conditions.
-	//     - boolean: false
-	//   "some-string-flag":
-	//     - string: "good"
-	//       conditions:
-	//         ...
+	// Cases are evaluated in order; the flag takes the value of the first case whose
+	// Conditions match. Must be non-empty, and every case must set the same one of
+	// Boolean/String/Number.
+	Cases []FeatureFlagCase `json:"cases"`
+}
+
+// FeatureFlagCase is one possible value of a FeatureFlag, gated by Conditions.
+type FeatureFlagCase struct {
+	// Exactly 1 of Boolean, String, or Number must be set.
+	Boolean *bool    `json:"boolean,omitempty"`
+	String  *string  `json:"string,omitempty"`
+	Number  *float64 `json:"number,omitempty"`
+
+	// Top-level conditions this case requires, "OR-joined": this case matches iff at least 1
+	// of them matches. Leaving this empty means the case always matches, which is only valid
+	// for the final case of Cases (it's the default).
+	Conditions []FeatureFlagConditionGroup `json:"conditions,omitempty"`
+}
+
+// FeatureFlagConditionGroup is a top-level condition of a FeatureFlagCase: it matches iff
+// every one of All's leaf conditions matches ("AND-joined").
+type FeatureFlagConditionGroup struct {
+	// Leaf conditions, AND-joined. Must be non-empty.
+	All []FeatureFlagCondition `json:"all"`
+}
+
+// FeatureFlagCondition is a single leaf condition a request either does or doesn't satisfy.
+// Exactly 1 of HasEmail or Random must be set.
+type FeatureFlagCondition struct {
+	// Glob (matched with path.Match) against the "email" claim of a JWT attached to the
+	// request, which is only considered if the JWT's "email_verified" claim is true and it
+	// validates against one of the owning Server's Auth.Jwks.
+	HasEmail *string `json:"hasEmail,omitempty"`
+
+	// Percentage (e.g. "50%") of requests that should match, determined by a deterministic
+	// hash of the request identifier so that a given request consistently lands on the same
+	// side of the gate across evaluations.
+	Random *string `json:"random,omitempty"`
 }
 
 // ServerStatus defines the observed state of Server
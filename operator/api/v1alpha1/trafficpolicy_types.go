@@ -0,0 +1,138 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// TrafficPolicySpec defines the desired state of a TrafficPolicy.
+type TrafficPolicySpec struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// ID of the Server this policy applies to. Must be in the same namespace.
+	Server string `json:"server"`
+
+	// Optional glob (matched with path.Match) restricting this policy to a subset of the
+	// Server's fully-qualified gRPC service names. If empty, the policy applies to every
+	// service on the Server.
+	Service string `json:"service,omitempty"`
+
+	// Optional glob of method names within the matched service(s). DomainReconciler does not
+	// read this field when generating routes: a policy always applies to every method of the
+	// services it matches.
+	Method string `json:"method,omitempty"`
+
+	// How long a matching request may run before the gateway cancels it.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retry budget for matching requests.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Mirrors a fraction of matching requests to another Server version, best-effort and
+	// without waiting for (or propagating) the mirrored response.
+	Mirror *MirrorPolicy `json:"mirror,omitempty"`
+
+	// Header-based canary rules that override VersionWeights when a request's headers match.
+	// Evaluated in order; the first matching rule wins.
+	HeaderMatch []HeaderMatchRule `json:"headerMatch,omitempty"`
+}
+
+// RetryPolicy configures gRPC-level retries. Used both by TrafficPolicy (per-method overrides)
+// and by DomainSpec/ServerSpec (defaults that apply absent a more specific TrafficPolicy); see
+// DomainReconciler.effectiveRetry for how the 2 are merged.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the original request.
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Timeout applied to each individual attempt.
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+
+	// gRPC status codes that should be retried, e.g. "UNAVAILABLE", "DEADLINE_EXCEEDED".
+	RetryOn []string `json:"retryOn,omitempty"`
+
+	// Initial delay before the first retry.
+	BackoffBase *metav1.Duration `json:"backoffBase,omitempty"`
+
+	// Upper bound the delay is capped at as attempts increase.
+	BackoffMax *metav1.Duration `json:"backoffMax,omitempty"`
+}
+
+// TimeoutPolicy configures gRPC-level request timeouts for a DomainSpec or ServerSpec; see
+// DomainReconciler.effectiveTimeout for how the 2 are merged, and with a TrafficPolicy's own
+// (narrower) Timeout.
+type TimeoutPolicy struct {
+	// How long a matching request may run end-to-end before the gateway cancels it.
+	Request *metav1.Duration `json:"request,omitempty"`
+
+	// How long a single backend attempt may run before it's considered failed, distinct from
+	// Request when Retry allows more than 1 attempt.
+	BackendRequest *metav1.Duration `json:"backendRequest,omitempty"`
+}
+
+// MirrorPolicy configures request mirroring for requests matching a TrafficPolicy.
+type MirrorPolicy struct {
+	// Percentage of matching requests to mirror, from 0 to 100.
+	Percent int32 `json:"percent,omitempty"`
+
+	// ID of the Server to mirror to. Defaults to the same Server (Spec.Server) if empty.
+	Server string `json:"server,omitempty"`
+
+	// Version of the target Server to mirror to.
+	Version string `json:"version"`
+}
+
+// HeaderMatchRule overrides the Server's VersionWeights for requests whose headers match.
+type HeaderMatchRule struct {
+	// Name of the gRPC metadata header to match against.
+	Name string `json:"name"`
+
+	// Exact value the header must have for this rule to apply.
+	Value string `json:"value"`
+
+	// Version weights to use instead of the Server's VersionWeights when this rule matches.
+	VersionWeights map[string]int32 `json:"versionWeights"`
+}
+
+// TrafficPolicyStatus defines the observed state of a TrafficPolicy.
+type TrafficPolicyStatus struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// Status conditions of the TrafficPolicy instance.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// TrafficPolicy is the Schema for the trafficpolicies API. It expresses per-method gRPC
+// routing behavior (timeouts, retries, mirroring, header-based canaries) that DomainReconciler
+// merges into the GRPCRoute it generates for the targeted Server's domain.
+type TrafficPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TrafficPolicySpec   `json:"spec,omitempty"`
+	Status TrafficPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TrafficPolicyList contains a list of TrafficPolicy.
+type TrafficPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TrafficPolicy{}, &TrafficPolicyList{})
+}
+
+// Return a pointer to the slice of conditions for this resource.
+func (resource *TrafficPolicy) GetConditions() *[]metav1.Condition {
+	return &resource.Status.Conditions
+}
@@ -1,9 +1,34 @@
 package v1alpha1
 
 import (
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DnsProvider identifies which DNS API the credentials in a DnsSpec authenticate against.
+type DnsProvider string
+
+const (
+	DnsProviderRoute53    DnsProvider = "Route53"
+	DnsProviderCloudDNS   DnsProvider = "CloudDNS"
+	DnsProviderCloudflare DnsProvider = "Cloudflare"
+)
+
+// DnsSpec configures the DNS subsystem that publishes weighted/latency/geo records for every
+// Domain's canonical hostname and aliases, and fails them over to Domain.Spec.Failover when the
+// domain's Gateway listener stops being Programmed.
+type DnsSpec struct {
+	// Which DNS provider API the credentials in SecretRef authenticate against.
+	Provider DnsProvider `json:"provider"`
+
+	// Name of the hosted zone (or equivalent) in which records are published.
+	Zone string `json:"zone"`
+
+	// Secret, in the Vimana's own namespace, holding the provider's API credentials.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
 // NOTE: json tags are required.
 //   Any new fields you add must have json tags for the fields to be serialized.
 
@@ -20,6 +45,57 @@ type VimanaSpec struct {
 	// Hostname and optional port of the image registry
 	// used for all component images within this Vimana cluster.
 	Registry string `json:"registry,omitempty"`
+
+	// Namespace in which listener TLS certificate Secrets are stored.
+	// If empty, each Domain's certificate Secret is expected to live in the Domain's own namespace.
+	// When set to a different namespace than the Gateway's, a ReferenceGrant is maintained
+	// in this namespace to permit the Gateway to read the certificate Secrets.
+	CertificateNamespace string `json:"certificateNamespace,omitempty"`
+
+	// Default cert-manager issuer used to provision each listener's TLS certificate.
+	// Can be overridden per Domain. If left unset, no Certificate is provisioned,
+	// and the listener's certificate Secret must be supplied by some other means.
+	CertificateIssuer cmmeta.ObjectReference `json:"certificateIssuer,omitempty"`
+
+	// DNS subsystem configuration. If left unset, no DNS records are published for any Domain
+	// owned by this Vimana.
+	Dns *DnsSpec `json:"dns,omitempty"`
+
+	// cert-manager issuer used to provision the per-Component backend certificate that secures
+	// gateway-to-backend traffic, gated by the BackendMTLS feature (see pkg/features). If left
+	// unset, BackendMTLS has no effect and backend traffic stays cleartext H2C.
+	BackendCertificateIssuer cmmeta.ObjectReference `json:"backendCertificateIssuer,omitempty"`
+
+	// ConfigMap, in the Vimana's own namespace, holding the CA bundle (key "ca.crt") that
+	// validates certificates issued by BackendCertificateIssuer. Referenced by the
+	// BackendTLSPolicy generated for each backend Service once BackendMTLS is enabled.
+	BackendCertificateCABundle *corev1.LocalObjectReference `json:"backendCertificateCABundle,omitempty"`
+
+	// Authentication for Registry. If left unset, Registry is assumed to allow anonymous pulls.
+	// Injected into every Component Pod's imagePullSecrets, and into the namespace's default
+	// ServiceAccount, so Pods created outside this operator's own Deployments can pull from
+	// Registry too.
+	RegistryAuth *RegistryAuth `json:"registryAuth,omitempty"`
+}
+
+// RegistryAuth authenticates against VimanaSpec.Registry. Exactly 1 of SecretRef or the
+// Username/Password pair must be set.
+type RegistryAuth struct {
+	// An existing Secret, in the Vimana's own namespace, of type kubernetes.io/dockerconfigjson.
+	// Used as-is; the controller never writes to it.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Username and password, each a key within a Secret in the Vimana's own namespace, from
+	// which the controller assembles a kubernetes.io/dockerconfigjson Secret of its own.
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+
+	// How often to re-read Username/Password and rewrite the generated Secret in place, for
+	// registries (e.g. ECR, GCR) that hand out short-lived tokens some external process
+	// refreshes at the same keys. Ignored when SecretRef is set instead, since that Secret is
+	// assumed to already be kept fresh externally. If left unset, the Secret is only
+	// regenerated when this Vimana otherwise reconciles.
+	Refresher *metav1.Duration `json:"refresher,omitempty"`
 }
 
 // VimanaStatus defines the observed state of a Vimana cluster.
@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// RolloutStrategyType selects how a VimanaRollout advances TargetVersion through Order.
+type RolloutStrategyType string
+
+const (
+	// Every region in Order is promoted to TargetVersion at weight 100 in a single pass, as
+	// soon as the previous region reaches Ready.
+	RolloutStrategyAllAtOnce RolloutStrategyType = "AllAtOnce"
+
+	// Identical to AllAtOnce. Kept as a distinct, explicit value for rollout authors who want to
+	// say "one region after another" without relying on AllAtOnce's default behavior.
+	RolloutStrategySequential RolloutStrategyType = "Sequential"
+
+	// Each region is walked through Steps before being considered promoted, rather than jumping
+	// straight to weight 100.
+	RolloutStrategyCanary RolloutStrategyType = "Canary"
+)
+
+// RolloutStep is 1 stage of a Canary rollout within a single region.
+type RolloutStep struct {
+	// Percentage weight TargetVersion should carry during this step, out of 100. The
+	// complementary weight is left on whichever single other version a Server is currently
+	// running; Servers running more than 1 other version are skipped (see
+	// VimanaRolloutReconciler.applyVersionWeight).
+	Weight int32 `json:"weight"`
+
+	// Minimum time to hold at Weight before advancing to the next step, measured from when the
+	// step began (Status.StepStartedAt); the step's Deployments must also be Ready. The final
+	// step's Pause is not applied.
+	Pause *metav1.Duration `json:"pause,omitempty"`
+}
+
+// RolloutStrategy configures how a VimanaRollout advances TargetVersion through Order.
+type RolloutStrategy struct {
+	// Defaults to AllAtOnce if left empty.
+	Type RolloutStrategyType `json:"type,omitempty"`
+
+	// Canary steps applied within each region in turn. Only consulted when Type is Canary;
+	// ignored (and required to be non-empty to have any effect) otherwise.
+	Steps []RolloutStep `json:"steps,omitempty"`
+
+	// How long to wait for a region to reach Ready at its current step before considering it
+	// failed. Defaults to 10 minutes if unset.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Whether a region that fails to reach Ready within Timeout has TargetVersion's weight
+	// reverted to 0 on every Server in that region, rather than being left at its last-applied,
+	// degraded weight.
+	AutoRollback bool `json:"autoRollback,omitempty"`
+}
+
+// VimanaRolloutSpec defines the desired state of a VimanaRollout.
+type VimanaRolloutSpec struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// Vimana this rollout promotes. Must be in the same namespace; only 1 Vimana is permitted
+	// per namespace (see the Vimana validating webhook), so this mostly just documents intent.
+	VimanaRef corev1.LocalObjectReference `json:"vimanaRef"`
+
+	// Version being rolled out. Every Server under a promoted Domain has its
+	// Spec.VersionWeights shifted toward this version; see applyVersionWeight.
+	TargetVersion string `json:"targetVersion"`
+
+	// Region rollout order. A Domain is promoted at the first entry in Order that appears in
+	// its own Spec.Regions; a Domain with no Spec.Regions (unrestricted) is promoted at the
+	// first entry. If Order is empty, every Domain is promoted in a single step.
+	Order []string `json:"order,omitempty"`
+
+	// How the rollout advances through Order.
+	Strategy RolloutStrategy `json:"strategy,omitempty"`
+}
+
+// VimanaRolloutStatus defines the observed state of a VimanaRollout.
+type VimanaRolloutStatus struct {
+	// Important: Run `bazel run //operator:generate` to regenerate code
+	//   after modifying this file.
+
+	// Status conditions of the VimanaRollout instance: RolloutProgressing (True while advancing
+	// or paused at a step, False once every region is done) and RolloutDegraded (True once the
+	// current step has missed Strategy.Timeout).
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// Region currently being promoted, i.e. the Order entry the rollout is waiting on. Empty
+	// once every region has been promoted.
+	CurrentRegion string `json:"currentRegion,omitempty"`
+
+	// Index into Strategy.Steps the current region is waiting on. Always 0 outside the Canary
+	// strategy.
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// When CurrentRegion/CurrentStep was last advanced. Strategy.Timeout and Strategy.Steps'
+	// Pause are both measured from here.
+	StepStartedAt *metav1.Time `json:"stepStartedAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VimanaRollout is the Schema for the vimanarollouts API. It turns a version bump into a staged
+// rollout across the regions a Vimana's Domains are restricted to, instead of a big-bang
+// replacement; see VimanaRolloutReconciler.
+type VimanaRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VimanaRolloutSpec   `json:"spec,omitempty"`
+	Status VimanaRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VimanaRolloutList contains a list of VimanaRollout.
+type VimanaRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VimanaRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VimanaRollout{}, &VimanaRolloutList{})
+}
+
+// Return a pointer to the slice of conditions for this resource.
+func (resource *VimanaRollout) GetConditions() *[]metav1.Condition {
+	return &resource.Status.Conditions
+}
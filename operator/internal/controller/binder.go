@@ -0,0 +1,335 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Name the Gateway controller reports itself as in RouteParentStatus.ControllerName.
+// Must match expectedGatewayClass.Spec.ControllerName.
+const gatewayControllerName = gwapi.GatewayController("gateway.envoyproxy.io/gatewayclass-controller")
+
+const (
+	reasonNoMatchingListenerHostname = "NoMatchingListenerHostname"
+	reasonNotAllowedByListeners      = "NotAllowedByListeners"
+	reasonInvalidKind                = "InvalidKind"
+	reasonHostnameConflict           = "HostnameConflict"
+)
+
+// routeBinding is a uniform view over a GRPCRoute or HTTPRoute,
+// the only 2 route kinds this binder currently knows how to attach to a Gateway.
+type routeBinding struct {
+	object     client.Object
+	kind       string
+	parentRefs []gwapi.ParentReference
+	hostnames  []gwapi.Hostname
+	setParents func([]gwapi.RouteParentStatus)
+}
+
+func grpcRouteBinding(route *gwapi.GRPCRoute) routeBinding {
+	return routeBinding{
+		object:     route,
+		kind:       "GRPCRoute",
+		parentRefs: route.Spec.ParentRefs,
+		hostnames:  route.Spec.Hostnames,
+		setParents: func(statuses []gwapi.RouteParentStatus) { route.Status.Parents = statuses },
+	}
+}
+
+func httpRouteBinding(route *gwapi.HTTPRoute) routeBinding {
+	return routeBinding{
+		object:     route,
+		kind:       "HTTPRoute",
+		parentRefs: route.Spec.ParentRefs,
+		hostnames:  route.Spec.Hostnames,
+		setParents: func(statuses []gwapi.RouteParentStatus) { route.Status.Parents = statuses },
+	}
+}
+
+// bindRoutes resolves every GRPCRoute/HTTPRoute in the Gateway's namespace against gateway's
+// listeners, writes each route's RouteParentStatus, and returns the per-listener attached
+// route count and the set of listener names whose hostname collides with another listener's.
+func (r *VimanaReconciler) bindRoutes(
+	ctx context.Context, gateway *gwapi.Gateway,
+) (attachedRoutes map[gwapi.SectionName]int32, conflictedListeners map[gwapi.SectionName]bool, err error) {
+	logger := log.FromContext(ctx)
+
+	grpcRoutes := &gwapi.GRPCRouteList{}
+	if err = r.List(ctx, grpcRoutes, client.InNamespace(gateway.Namespace)); err != nil {
+		logger.Error(err, "Failed to list GRPCRoutes", "namespace", gateway.Namespace)
+		return nil, nil, err
+	}
+	httpRoutes := &gwapi.HTTPRouteList{}
+	if err = r.List(ctx, httpRoutes, client.InNamespace(gateway.Namespace)); err != nil {
+		logger.Error(err, "Failed to list HTTPRoutes", "namespace", gateway.Namespace)
+		return nil, nil, err
+	}
+
+	bindings := make([]routeBinding, 0, len(grpcRoutes.Items)+len(httpRoutes.Items))
+	for i := range grpcRoutes.Items {
+		bindings = append(bindings, grpcRouteBinding(&grpcRoutes.Items[i]))
+	}
+	for i := range httpRoutes.Items {
+		bindings = append(bindings, httpRouteBinding(&httpRoutes.Items[i]))
+	}
+
+	conflictedListeners = conflictingHostnameListeners(gateway)
+	attachedRoutes = make(map[gwapi.SectionName]int32, len(gateway.Spec.Listeners))
+
+	for _, binding := range bindings {
+		if len(binding.parentRefs) == 0 {
+			continue
+		}
+		statuses := make([]gwapi.RouteParentStatus, 0, len(binding.parentRefs))
+		for _, parentRef := range binding.parentRefs {
+			if !parentRefTargetsGateway(parentRef, gateway) {
+				continue
+			}
+			statuses = append(statuses, bindRouteToGateway(gateway, binding, parentRef, conflictedListeners, attachedRoutes))
+		}
+		if len(statuses) == 0 {
+			continue
+		}
+		binding.setParents(statuses)
+		if err = r.Status().Update(ctx, binding.object); err != nil {
+			logger.Error(err, "Failed to update route status", "namespace", binding.object.GetNamespace(), "name", binding.object.GetName())
+			return nil, nil, err
+		}
+	}
+
+	return attachedRoutes, conflictedListeners, nil
+}
+
+// parentRefTargetsGateway reports whether parentRef names this Gateway,
+// defaulting an absent Group/Kind/Namespace per the Gateway API spec.
+func parentRefTargetsGateway(parentRef gwapi.ParentReference, gateway *gwapi.Gateway) bool {
+	if parentRef.Group != nil && *parentRef.Group != "" && *parentRef.Group != gwapi.Group("gateway.networking.k8s.io") {
+		return false
+	}
+	if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
+		return false
+	}
+	if parentRef.Namespace != nil && string(*parentRef.Namespace) != gateway.Namespace {
+		return false
+	}
+	return string(parentRef.Name) == gateway.Name
+}
+
+// bindRouteToGateway resolves a single parentRef against gateway's listeners and returns the
+// RouteParentStatus to record for it, incrementing attachedRoutes for every listener the route
+// actually binds to.
+func bindRouteToGateway(
+	gateway *gwapi.Gateway,
+	route routeBinding,
+	parentRef gwapi.ParentReference,
+	conflictedListeners map[gwapi.SectionName]bool,
+	attachedRoutes map[gwapi.SectionName]int32,
+) gwapi.RouteParentStatus {
+	var candidates []gwapi.Listener
+	for _, listener := range gateway.Spec.Listeners {
+		if parentRef.SectionName == nil || *parentRef.SectionName == listener.Name {
+			candidates = append(candidates, listener)
+		}
+	}
+	if len(candidates) == 0 {
+		return rejectedRouteParentStatus(parentRef, reasonNotAllowedByListeners, "No listener with the requested sectionName exists on this Gateway")
+	}
+
+	sawAllowedKind := false
+	attached := false
+	for _, listener := range candidates {
+		if conflictedListeners[listener.Name] {
+			continue
+		}
+		if !listenerAllowsKind(listener, route.kind) {
+			continue
+		}
+		sawAllowedKind = true
+		if !hostnamesIntersect(listener.Hostname, route.hostnames) {
+			continue
+		}
+		attached = true
+		attachedRoutes[listener.Name]++
+	}
+
+	switch {
+	case attached:
+		return acceptedRouteParentStatus(parentRef)
+	case !sawAllowedKind:
+		return rejectedRouteParentStatus(parentRef, reasonInvalidKind, fmt.Sprintf("No listener allows routes of kind %q", route.kind))
+	default:
+		return rejectedRouteParentStatus(parentRef, reasonNoMatchingListenerHostname, "No listener hostname intersects this route's hostnames")
+	}
+}
+
+// listenerAllowsKind reports whether listener's AllowedRoutes permits the given route kind.
+// A listener with no configured AllowedRoutes.Kinds allows every kind this controller manages.
+func listenerAllowsKind(listener gwapi.Listener, kind string) bool {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return true
+	}
+	for _, allowed := range listener.AllowedRoutes.Kinds {
+		if string(allowed.Kind) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// hostnamesIntersect reports whether at least 1 of routeHostnames is covered by
+// listenerHostname, per the Gateway API hostname intersection rules.
+// A nil listenerHostname or an empty routeHostnames both mean "matches anything".
+func hostnamesIntersect(listenerHostname *gwapi.Hostname, routeHostnames []gwapi.Hostname) bool {
+	if listenerHostname == nil || len(routeHostnames) == 0 {
+		return true
+	}
+	for _, routeHostname := range routeHostnames {
+		if hostnamesMatch(string(*listenerHostname), string(routeHostname)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostnamesMatch reports whether a and b are the same hostname,
+// or whether one is a wildcard (e.g. `*.example.com`) covering the other.
+func hostnamesMatch(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if wildcardCovers(a, b) || wildcardCovers(b, a) {
+		return true
+	}
+	return false
+}
+
+func wildcardCovers(wildcard, hostname string) bool {
+	suffix, isWildcard := strings.CutPrefix(wildcard, "*.")
+	if !isWildcard {
+		return false
+	}
+	return strings.HasSuffix(hostname, "."+suffix)
+}
+
+// conflictingHostnameListeners returns the set of listener names whose Hostname is shared
+// with another listener on the same Gateway. Such listeners are never attachable: a Gateway
+// cannot actually serve 2 listeners that claim the same hostname.
+func conflictingHostnameListeners(gateway *gwapi.Gateway) map[gwapi.SectionName]bool {
+	byHostname := make(map[gwapi.Hostname][]gwapi.SectionName)
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.Hostname == nil {
+			continue
+		}
+		byHostname[*listener.Hostname] = append(byHostname[*listener.Hostname], listener.Name)
+	}
+	conflicted := make(map[gwapi.SectionName]bool)
+	for _, names := range byHostname {
+		if len(names) > 1 {
+			for _, name := range names {
+				conflicted[name] = true
+			}
+		}
+	}
+	return conflicted
+}
+
+func acceptedRouteParentStatus(parentRef gwapi.ParentReference) gwapi.RouteParentStatus {
+	now := metav1.Now()
+	return gwapi.RouteParentStatus{
+		ParentRef:      parentRef,
+		ControllerName: gatewayControllerName,
+		Conditions: []metav1.Condition{
+			{
+				Type:               string(gwapi.RouteConditionAccepted),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gwapi.RouteReasonAccepted),
+				Message:            "Route is bound to the matched Gateway listener(s)",
+				LastTransitionTime: now,
+			},
+			{
+				Type:               string(gwapi.RouteConditionResolvedRefs),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gwapi.RouteReasonResolvedRefs),
+				Message:            "All backend references resolved",
+				LastTransitionTime: now,
+			},
+		},
+	}
+}
+
+func rejectedRouteParentStatus(parentRef gwapi.ParentReference, reason, message string) gwapi.RouteParentStatus {
+	return gwapi.RouteParentStatus{
+		ParentRef:      parentRef,
+		ControllerName: gatewayControllerName,
+		Conditions: []metav1.Condition{
+			{
+				Type:               string(gwapi.RouteConditionAccepted),
+				Status:             metav1.ConditionFalse,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: metav1.Now(),
+			},
+		},
+	}
+}
+
+// applyListenerStatuses populates gateway.Status.Listeners from the outcome of bindRoutes.
+func applyListenerStatuses(gateway *gwapi.Gateway, attachedRoutes map[gwapi.SectionName]int32, conflictedListeners map[gwapi.SectionName]bool) {
+	statuses := make([]gwapi.ListenerStatus, 0, len(gateway.Spec.Listeners))
+	for _, listener := range gateway.Spec.Listeners {
+		now := metav1.Now()
+		conditions := []metav1.Condition{
+			{
+				Type:               string(gwapi.ListenerConditionResolvedRefs),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gwapi.ListenerReasonResolvedRefs),
+				Message:            "Certificate and route references resolved",
+				LastTransitionTime: now,
+			},
+		}
+		if conflictedListeners[listener.Name] {
+			conditions = append(conditions,
+				metav1.Condition{
+					Type: string(gwapi.ListenerConditionAccepted), Status: metav1.ConditionFalse,
+					Reason: reasonHostnameConflict, Message: "Another listener on this Gateway claims the same hostname", LastTransitionTime: now,
+				},
+				metav1.Condition{
+					Type: string(gwapi.ListenerConditionProgrammed), Status: metav1.ConditionFalse,
+					Reason: reasonHostnameConflict, Message: "Another listener on this Gateway claims the same hostname", LastTransitionTime: now,
+				},
+			)
+		} else {
+			conditions = append(conditions,
+				metav1.Condition{
+					Type: string(gwapi.ListenerConditionAccepted), Status: metav1.ConditionTrue,
+					Reason: string(gwapi.ListenerReasonAccepted), Message: "Listener accepted", LastTransitionTime: now,
+				},
+				metav1.Condition{
+					Type: string(gwapi.ListenerConditionProgrammed), Status: metav1.ConditionTrue,
+					Reason: string(gwapi.ListenerReasonProgrammed), Message: "Listener programmed", LastTransitionTime: now,
+				},
+			)
+		}
+
+		statuses = append(statuses, gwapi.ListenerStatus{
+			Name:           listener.Name,
+			SupportedKinds: supportedKinds(listener),
+			AttachedRoutes: attachedRoutes[listener.Name],
+			Conditions:     conditions,
+		})
+	}
+	gateway.Status.Listeners = statuses
+}
+
+func supportedKinds(listener gwapi.Listener) []gwapi.RouteGroupKind {
+	if listener.AllowedRoutes == nil {
+		return nil
+	}
+	return listener.AllowedRoutes.Kinds
+}
@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// Label applied to every Certificate this controller manages, identifying the owning Vimana so
+// that stale Certificates can be found and removed even when they live in a different namespace
+// than the Vimana (when CertificateNamespace is set).
+const labelCertificateOwnerKey = "vimana.host/certificate-owner"
+
+// certificateRequest describes 1 hostname (a domain's canonical name or 1 of its aliases)
+// that may need a cert-manager Certificate provisioning its listener TLS Secret.
+type certificateRequest struct {
+	hostname string
+	issuer   cmmeta.ObjectReference
+}
+
+// Return true iff the two objects are *not* equal.
+func certificateSpecDiffers(actual, expected *cmapi.Certificate) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
+}
+
+// Mutate the "spec" value of the receiver to match that of the other object.
+func certificateCopySpec(receiver, giver *cmapi.Certificate) {
+	receiver.Spec = giver.Spec
+}
+
+// reconcileCertificates ensures a cert-manager Certificate exists for every certificateRequest
+// whose issuer is configured, provisioning the same `c-<hash>` Secret that the Gateway's
+// listener already expects to find. Requests with a zero-value issuer are left alone entirely,
+// on the assumption that the caller populates the Secret by some other means. Likewise, if the
+// Secret already exists and no Certificate we previously created owns it, it's left alone: this
+// is the "bring your own secret" bypass, so callers who pre-populate `c-<hash>` themselves are
+// never disturbed.
+func (r *VimanaReconciler) reconcileCertificates(
+	ctx context.Context, vimana *apiv1alpha1.Vimana, certNamespace string, requests []certificateRequest,
+) error {
+	logger := log.FromContext(ctx)
+
+	ownerLabel := hashed(fmt.Sprintf("%s/%s", vimana.Namespace, vimana.Name))
+	existing := &cmapi.CertificateList{}
+	if err := r.List(ctx, existing, client.MatchingLabels{labelCertificateOwnerKey: ownerLabel}); err != nil {
+		logger.Error(err, "Failed to list Certificates", "vimana", vimana.Name)
+		return err
+	}
+	existingByName := make(map[string]*cmapi.Certificate, len(existing.Items))
+	for i := range existing.Items {
+		existingByName[existing.Items[i].Name] = &existing.Items[i]
+	}
+
+	wanted := map[string]*cmapi.Certificate{}
+	for _, req := range requests {
+		if req.issuer == (cmmeta.ObjectReference{}) {
+			// No issuer configured for this hostname: leave its Secret to be populated however
+			// the caller intends, managing nothing.
+			continue
+		}
+		secretName := certSecretName(req.hostname)
+		if _, alreadyManaged := existingByName[secretName]; !alreadyManaged {
+			secret := &corev1.Secret{}
+			err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: certNamespace}, secret)
+			if err == nil {
+				// A Secret with this name already exists, and we've never created a Certificate
+				// for it: assume it's supplied by the caller and don't touch it.
+				continue
+			} else if !apierrors.IsNotFound(err) {
+				logger.Error(err, "Failed to look up certificate Secret", "namespace", certNamespace, "name", secretName)
+				return err
+			}
+		}
+
+		wanted[secretName] = &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: certNamespace,
+				Labels:    map[string]string{labelCertificateOwnerKey: ownerLabel},
+			},
+			Spec: cmapi.CertificateSpec{
+				SecretName: secretName,
+				DNSNames:   []string{req.hostname},
+				IssuerRef:  req.issuer,
+			},
+		}
+	}
+
+	for name, expected := range wanted {
+		namespacedName := types.NamespacedName{Name: name, Namespace: certNamespace}
+		err := ensureResourceHasSpecAndLabels(
+			r.Client, ctx, namespacedName, &cmapi.Certificate{}, expected, certificateSpecDiffers, certificateCopySpec,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete any Certificate that is no longer wanted, including ones left behind in a
+	// namespace that is no longer this Vimana's CertificateNamespace.
+	for i := range existing.Items {
+		cert := &existing.Items[i]
+		if expected, ok := wanted[cert.Name]; ok && cert.Namespace == expected.Namespace {
+			continue
+		}
+		if err := r.Delete(ctx, cert); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete stale Certificate", "namespace", cert.Namespace, "name", cert.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// certificatesReadyCondition summarizes the Ready condition of every cert-manager Certificate
+// this Vimana manages into a single status condition, so that callers don't need to separately
+// watch Certificates to know whether a domain's TLS is actually provisioned.
+func (r *VimanaReconciler) certificatesReadyCondition(ctx context.Context, vimana *apiv1alpha1.Vimana) (metav1.Condition, error) {
+	logger := log.FromContext(ctx)
+
+	ownerLabel := hashed(fmt.Sprintf("%s/%s", vimana.Namespace, vimana.Name))
+	certificates := &cmapi.CertificateList{}
+	if err := r.List(ctx, certificates, client.MatchingLabels{labelCertificateOwnerKey: ownerLabel}); err != nil {
+		logger.Error(err, "Failed to list Certificates", "vimana", vimana.Name)
+		return metav1.Condition{}, err
+	}
+
+	if len(certificates.Items) == 0 {
+		return metav1.Condition{
+			Type: "CertificatesReady", Status: metav1.ConditionTrue,
+			Reason: "NoCertificatesManaged", Message: "No Certificates are managed by this Vimana",
+		}, nil
+	}
+
+	var notReady []string
+	for _, cert := range certificates.Items {
+		readyCondition := getCertManagerReadyCondition(cert.Status.Conditions)
+		if readyCondition == nil || readyCondition.Status != cmmeta.ConditionTrue {
+			notReady = append(notReady, cert.Name)
+		}
+	}
+	if len(notReady) == 0 {
+		return metav1.Condition{
+			Type: "CertificatesReady", Status: metav1.ConditionTrue,
+			Reason: "Issued", Message: "All managed Certificates are ready",
+		}, nil
+	}
+	return metav1.Condition{
+		Type: "CertificatesReady", Status: metav1.ConditionFalse,
+		Reason: "Issuing", Message: fmt.Sprintf("%d Certificate(s) not yet ready: %v", len(notReady), notReady),
+	}, nil
+}
+
+func getCertManagerReadyCondition(conditions []cmapi.CertificateCondition) *cmapi.CertificateCondition {
+	for i := range conditions {
+		if conditions[i].Type == cmapi.CertificateConditionReady {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
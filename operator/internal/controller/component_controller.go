@@ -2,33 +2,62 @@ package controller
 
 import (
 	"context"
-	"reflect"
+	"fmt"
+	"time"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+	"vimana.host/operator/pkg/features"
+	"vimana.host/operator/pkg/readiness"
 )
 
 const (
 	// The constant name of the single container that exists in each Vimana pod.
 	grpcContainerName = "grpc"
+
+	// How long to wait before checking readiness again while it hasn't yet converged.
+	readinessRequeueDelay = 5 * time.Second
+
+	conditionTypeDeploymentReady = "DeploymentReady"
+	conditionTypeEndpointsReady  = "EndpointsReady"
+
+	// Name of the volume/mount path for the backend certificate Secret, present on the grpc
+	// container only once reconcileBackendCertificate provisions one.
+	backendCertVolumeName = "backend-tls"
+	backendCertMountPath  = "/etc/vimana/backend-tls"
+
+	// Pod template annotation recording the backend certificate Secret's ResourceVersion, so
+	// that the Deployment rolls its pods whenever cert-manager rotates the certificate (the
+	// Secret's contents changing wouldn't otherwise be reflected in the Deployment's spec).
+	backendCertResourceVersionAnnotation = "vimana.host/backend-cert-resource-version"
 )
 
 var (
-	// gRPC requires HTTP/2,
-	// and traffic between the gateway and backends is cleartext.
-	// TODO: We should always encrypt both at rest and in transit (thanks Snowden). Figure that out before GA.
+	// gRPC requires HTTP/2. Traffic between the gateway and backends is cleartext by default;
+	// it switches to grpcTlsAppProtocol once reconcileBackendCertificate provisions a backend
+	// certificate (see features.BackendMTLS).
 	grpcAppProtocol = "kubernetes.io/h2c"
 
+	// AppProtocol recorded on the Service port once backend mTLS is provisioned.
+	grpcTlsAppProtocol = "kubernetes.io/h2"
+
 	// The pod spec requires the runtime class name to be expressed as a pointer.
 	runtimeClassNamePtr = ptr.To(runtimeClassName)
 )
@@ -37,29 +66,11 @@ var (
 type ComponentReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
-}
 
-// Return true iff the two objects are *not* equal.
-func deploymentSpecDiffers(actual, expected *appsv1.Deployment) bool {
-	// The number of replicas is controlled externally, probably by the HPA controller.
-	// Make sure not to modify it in this controller.
-	expected.Spec.Replicas = actual.Spec.Replicas
-	return !reflect.DeepEqual(actual.Spec, expected.Spec)
-}
-
-// Mutate the "spec" value of the receiver to match that of the other object.
-func deploymentCopySpec(receiver, giver *appsv1.Deployment) {
-	receiver.Spec = giver.Spec
-}
-
-// Return true iff the two objects are *not* equal.
-func serviceSpecDiffers(actual, expected *corev1.Service) bool {
-	return !reflect.DeepEqual(actual.Spec, expected.Spec)
-}
-
-// Mutate the "spec" value of the receiver to match that of the other object.
-func serviceCopySpec(receiver, giver *corev1.Service) {
-	receiver.Spec = giver.Spec
+	// APIReader reads directly from the API server, bypassing the manager's cache. It's needed
+	// for EndpointSlice, whose watch below is metadata-only (see SetupWithManager), which means
+	// the shared cached client can no longer serve full EndpointSlice objects for *any* reader.
+	APIReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=api.vimana.host,resources=components,verbs=get;list;watch;create;update;patch;delete
@@ -86,6 +97,17 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	healthy, err := r.regionsHealthy(ctx, component)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !healthy {
+		// The Domain's configured regions are all currently unhealthy: leave whatever
+		// Deployment/Service already exist alone rather than reconciling them against a
+		// failure domain that can't serve traffic.
+		return ctrl.Result{}, updateAvailabilityStatus(r.Client, ctx, component, metav1.ConditionFalse, "RegionsUnhealthy", "None of the owning Domain's regions are currently healthy")
+	}
+
 	labels := map[string]string{
 		labelDomainKey:  component.Spec.Domain,
 		labelServerKey:  component.Spec.Server,
@@ -98,6 +120,50 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		Name:      deploymentName,
 		Namespace: req.Namespace,
 	}
+	serviceName := prefixed(hashedName, 's')
+	serviceNamespacedName := types.NamespacedName{
+		Name:      serviceName,
+		Namespace: req.Namespace,
+	}
+
+	backendCertSecret, err := r.reconcileBackendCertificate(ctx, component, labels, hashedName, serviceName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	imagePullSecrets, err := r.registryPullSecretRefs(ctx, component)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	appProtocol := grpcAppProtocol
+	container := corev1.Container{
+		Name:  grpcContainerName,
+		Image: component.Spec.Image,
+		Env:   []corev1.EnvVar{},
+		// TODO: Switch to IfNotPresent in production.
+		//   For local testing, it's import to use Always, because images are effectively mutable;
+		//   they may change from run to run while iterating.
+		//   In production, however, images are immutable, so we can use IfNotPresent for better performance.
+		ImagePullPolicy: corev1.PullAlways,
+	}
+	var podVolumes []corev1.Volume
+	var podAnnotations map[string]string
+	if backendCertSecret != nil {
+		appProtocol = grpcTlsAppProtocol
+		container.VolumeMounts = []corev1.VolumeMount{
+			{Name: backendCertVolumeName, MountPath: backendCertMountPath, ReadOnly: true},
+		}
+		podVolumes = []corev1.Volume{
+			{
+				Name: backendCertVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: backendCertSecret.Name},
+				},
+			},
+		}
+		podAnnotations = map[string]string{backendCertResourceVersionAnnotation: backendCertSecret.ResourceVersion}
+	}
 
 	// Generate the corresponding Deployment.
 	expectedDeployment := &appsv1.Deployment{
@@ -110,26 +176,18 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
-			// Note that the replica count is set by `deploymentSpecDiffers` to match the actual value.
+			// Note that the replica count is set by deploymentHandler.Diff to match the actual value.
 			// If the resource does not yet exist, the default value of 1 replica would be used initially.
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					RuntimeClassName: runtimeClassNamePtr,
-					Containers: []corev1.Container{
-						{
-							Name:  grpcContainerName,
-							Image: component.Spec.Image,
-							Env:   []corev1.EnvVar{},
-							// TODO: Switch to IfNotPresent in production.
-							//   For local testing, it's import to use Always, because images are effectively mutable;
-							//   they may change from run to run while iterating.
-							//   In production, however, images are immutable, so we can use IfNotPresent for better performance.
-							ImagePullPolicy: corev1.PullAlways,
-						},
-					},
+					Containers:       []corev1.Container{container},
+					Volumes:          podVolumes,
+					ImagePullSecrets: imagePullSecrets,
 				},
 			},
 		},
@@ -141,18 +199,6 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	// Create or Update the Deployment.
-	err = ensureResourceHasSpecAndLabels(r.Client, ctx, deploymentNamespacedName, &appsv1.Deployment{}, expectedDeployment, deploymentSpecDiffers, deploymentCopySpec)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	serviceName := prefixed(hashedName, 's')
-	serviceNamespacedName := types.NamespacedName{
-		Name:      serviceName,
-		Namespace: req.Namespace,
-	}
-
 	// Generate the corresponding Service.
 	expectedService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -164,7 +210,7 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			Ports: []corev1.ServicePort{
 				{
 					Port:        grpcPortNumber,
-					AppProtocol: &grpcAppProtocol,
+					AppProtocol: &appProtocol,
 				},
 			},
 			Selector: labels,
@@ -177,20 +223,385 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	// Create or Update the Service.
-	err = ensureResourceHasSpecAndLabels(r.Client, ctx, serviceNamespacedName, &corev1.Service{}, expectedService, serviceSpecDiffers, serviceCopySpec)
+	// Create or Update the Service, then the Deployment, in installRank order (Service before
+	// Deployment, as in Helm 3's InstallOrder), short-circuiting to a requeue if the Service
+	// isn't actually ready yet rather than racing the Deployment's Pods against it.
+	result, err := applyOrdered(ctx, []orderedStep{
+		{
+			Kind: "Service",
+			Apply: func(ctx context.Context) error {
+				return ensureManagedResource(r.Client, ctx, serviceNamespacedName, &corev1.Service{}, expectedService)
+			},
+			Ready: func(ctx context.Context) (readiness.Entry, error) {
+				service := &corev1.Service{}
+				if err := r.Get(ctx, serviceNamespacedName, service); err != nil {
+					if apierrors.IsNotFound(err) {
+						// The cache may not have observed the Create yet; the owned-Service
+						// watch will re-trigger this reconcile once it has.
+						return readiness.Entry{Kind: "Service", Name: serviceNamespacedName.Name, Readiness: readiness.Readiness{Reason: "CachePending", Message: "Waiting for the Service to appear in the cache"}}, nil
+					}
+					return readiness.Entry{}, err
+				}
+				return readiness.Check(service), nil
+			},
+		},
+		{
+			Kind: "Deployment",
+			Apply: func(ctx context.Context) error {
+				return ensureManagedResource(r.Client, ctx, deploymentNamespacedName, &appsv1.Deployment{}, expectedDeployment)
+			},
+			// Whether the Deployment has actually rolled out is updateReadinessStatus's job below,
+			// which also reports EndpointSlice readiness and sets the Component's own Conditions;
+			// here we only need to know the Deployment has been created.
+			Ready: func(ctx context.Context) (readiness.Entry, error) {
+				deployment := &appsv1.Deployment{}
+				if err := r.Get(ctx, deploymentNamespacedName, deployment); err != nil {
+					if apierrors.IsNotFound(err) {
+						return readiness.Entry{Kind: "Deployment", Name: deploymentNamespacedName.Name, Readiness: readiness.Readiness{Reason: "CachePending", Message: "Waiting for the Deployment to appear in the cache"}}, nil
+					}
+					return readiness.Entry{}, err
+				}
+				return readiness.Entry{Kind: "Deployment", Name: deploymentNamespacedName.Name, Readiness: readiness.Readiness{Ready: true, Reason: "Present", Message: "Deployment exists"}}, nil
+			},
+		},
+	})
+	if err != nil || result != (ctrl.Result{}) {
+		return result, err
+	}
+
+	return r.updateReadinessStatus(ctx, component, deploymentNamespacedName, serviceNamespacedName)
+}
+
+// updateReadinessStatus mirrors the Deployment's and Service's actual rollout state onto
+// DeploymentReady and EndpointsReady conditions, then rolls both up into Available — the way
+// `helm install --wait` decides a release is actually up, rather than just "applied."
+// While either sub-condition is False, the Component is re-enqueued after a short delay so
+// readiness is picked up promptly without needing a watch on every intermediate pod event.
+func (r *ComponentReconciler) updateReadinessStatus(
+	ctx context.Context,
+	component *apiv1alpha1.Component,
+	deploymentNamespacedName, serviceNamespacedName types.NamespacedName,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !features.Enabled(features.HelmStyleReadiness) {
+		// Pre-readiness-gating behavior: a successful reconcile is immediately available.
+		err := updateAvailabilityStatus(r.Client, ctx, component, metav1.ConditionTrue, "Reconciled", "Successfully reconciled component")
+		return ctrl.Result{}, err
+	}
+
+	deploymentReady, deploymentReason, deploymentMessage, err := r.deploymentReadiness(ctx, deploymentNamespacedName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	meta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+		Type:    conditionTypeDeploymentReady,
+		Status:  boolCondition(deploymentReady),
+		Reason:  deploymentReason,
+		Message: deploymentMessage,
+	})
+
+	endpointsReady, endpointsReason, endpointsMessage, err := r.endpointsReadiness(ctx, serviceNamespacedName)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	meta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+		Type:    conditionTypeEndpointsReady,
+		Status:  boolCondition(endpointsReady),
+		Reason:  endpointsReason,
+		Message: endpointsMessage,
+	})
 
+	available := deploymentReady && endpointsReady
+	availableReason, availableMessage := "Reconciled", "Successfully reconciled component"
+	if !available {
+		availableReason, availableMessage = "NotReady", "Waiting for the Deployment and its endpoints to become ready"
+	}
+	meta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+		Type:    conditionTypeAvailable,
+		Status:  boolCondition(available),
+		Reason:  availableReason,
+		Message: availableMessage,
+	})
+
+	if err := r.Status().Update(ctx, component); err != nil {
+		logger.Error(err, "Failed to update Component status", "namespace", component.Namespace, "name", component.Name)
+		return ctrl.Result{}, err
+	}
+
+	if !available {
+		return ctrl.Result{RequeueAfter: readinessRequeueDelay}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
+// reconcileBackendCertificate provisions the cert-manager Certificate that secures this
+// Component's backend traffic, gated by features.BackendMTLS and
+// VimanaSpec.BackendCertificateIssuer (see backendCertificateIssuer). Its DNS SAN is the
+// Component's Service name, matched on the gateway side by the BackendTLSPolicy
+// DomainReconciler generates for that Service. It returns the Secret backing the certificate so
+// the caller can mount it and stamp its ResourceVersion onto the pod template for rotation; nil
+// is returned, after deleting any previously-managed Certificate, whenever mTLS isn't configured,
+// and also when cert-manager hasn't issued the Secret yet (the owned-Certificate watch re-triggers
+// this reconcile once it has).
+func (r *ComponentReconciler) reconcileBackendCertificate(
+	ctx context.Context, component *apiv1alpha1.Component, labels map[string]string, hashedName, serviceName string,
+) (*corev1.Secret, error) {
+	certNamespacedName := types.NamespacedName{Name: prefixed(hashedName, 'm'), Namespace: component.Namespace}
+
+	issuer, err := r.backendCertificateIssuer(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	if !features.Enabled(features.BackendMTLS) || issuer == (cmmeta.ObjectReference{}) {
+		return nil, ensureResourceDeleted(r.Client, ctx, certNamespacedName, &cmapi.Certificate{})
+	}
+
+	expected := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      certNamespacedName.Name,
+			Namespace: certNamespacedName.Namespace,
+			Labels:    labels,
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName: certNamespacedName.Name,
+			DNSNames:   []string{serviceName},
+			IssuerRef:  issuer,
+			Usages:     []cmapi.KeyUsage{cmapi.UsageServerAuth},
+		},
+	}
+	if err := ctrl.SetControllerReference(component, expected, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := ensureResourceHasSpecAndLabels(r.Client, ctx, certNamespacedName, &cmapi.Certificate{}, expected, certificateSpecDiffers, certificateCopySpec); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, certNamespacedName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// backendCertificateIssuer resolves the cert-manager issuer that secures this Component's
+// backend traffic from the owning Domain's Vimana (see VimanaSpec.BackendCertificateIssuer). A
+// missing Domain or Vimana isn't this reconciler's concern (see regionsHealthy); it's treated as
+// "no issuer configured," so mTLS is left disabled rather than failing the reconcile.
+func (r *ComponentReconciler) backendCertificateIssuer(ctx context.Context, component *apiv1alpha1.Component) (cmmeta.ObjectReference, error) {
+	logger := log.FromContext(ctx)
+
+	domain := &apiv1alpha1.Domain{}
+	if err := r.Get(ctx, types.NamespacedName{Name: component.Spec.Domain, Namespace: component.Namespace}, domain); err != nil {
+		if apierrors.IsNotFound(err) {
+			return cmmeta.ObjectReference{}, nil
+		}
+		logger.Error(err, "Failed to get Domain for Component", "namespace", component.Namespace, "name", component.Name)
+		return cmmeta.ObjectReference{}, err
+	}
+
+	vimana := &apiv1alpha1.Vimana{}
+	if err := r.Get(ctx, types.NamespacedName{Name: domain.Spec.Vimana, Namespace: component.Namespace}, vimana); err != nil {
+		if apierrors.IsNotFound(err) {
+			return cmmeta.ObjectReference{}, nil
+		}
+		logger.Error(err, "Failed to get Vimana for Component", "namespace", component.Namespace, "name", component.Name)
+		return cmmeta.ObjectReference{}, err
+	}
+	return vimana.Spec.BackendCertificateIssuer, nil
+}
+
+// registryPullSecretRefs resolves the imagePullSecrets component's Pod should carry, by way of
+// the owning Domain's Vimana; see backendCertificateIssuer for why a missing Domain or Vimana is
+// treated as "nothing configured" rather than an error.
+func (r *ComponentReconciler) registryPullSecretRefs(ctx context.Context, component *apiv1alpha1.Component) ([]corev1.LocalObjectReference, error) {
+	logger := log.FromContext(ctx)
+
+	domain := &apiv1alpha1.Domain{}
+	if err := r.Get(ctx, types.NamespacedName{Name: component.Spec.Domain, Namespace: component.Namespace}, domain); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Error(err, "Failed to get Domain for Component", "namespace", component.Namespace, "name", component.Name)
+		return nil, err
+	}
+
+	vimana := &apiv1alpha1.Vimana{}
+	if err := r.Get(ctx, types.NamespacedName{Name: domain.Spec.Vimana, Namespace: component.Namespace}, vimana); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Error(err, "Failed to get Vimana for Component", "namespace", component.Namespace, "name", component.Name)
+		return nil, err
+	}
+
+	secretName := registryPullSecretName(vimana)
+	if secretName == "" {
+		return nil, nil
+	}
+	return []corev1.LocalObjectReference{{Name: secretName}}, nil
+}
+
+// boolCondition maps a readiness outcome onto the metav1.Condition status it belongs in.
+func boolCondition(ready bool) metav1.ConditionStatus {
+	if ready {
+		return metav1.ConditionTrue
+	}
+	return metav1.ConditionFalse
+}
+
+// deploymentReadiness decides whether the owned Deployment has actually rolled out, following
+// the same checks Helm's readiness wait performs: the controller has observed the latest spec,
+// and every desired replica is both updated and available.
+func (r *ComponentReconciler) deploymentReadiness(ctx context.Context, namespacedName types.NamespacedName) (ready bool, reason, message string, err error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, namespacedName, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, "DeploymentNotFound", "Deployment does not exist yet", nil
+		}
+		return false, "", "", err
+	}
+
+	if progressing := findDeploymentCondition(deployment, appsv1.DeploymentProgressing); progressing != nil &&
+		progressing.Status == corev1.ConditionFalse && progressing.Reason == "ProgressDeadlineExceeded" {
+		return false, "ProgressDeadlineExceeded", progressing.Message, nil
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false, "ObservedGenerationStale", "Deployment controller has not yet observed the latest spec", nil
+	}
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas != desired {
+		return false, "RolloutInProgress", fmt.Sprintf("%d/%d replicas updated", deployment.Status.UpdatedReplicas, desired), nil
+	}
+	if deployment.Status.AvailableReplicas != desired {
+		return false, "RolloutInProgress", fmt.Sprintf("%d/%d replicas available", deployment.Status.AvailableReplicas, desired), nil
+	}
+
+	return true, "RolloutComplete", "All replicas updated and available", nil
+}
+
+func findDeploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range deployment.Status.Conditions {
+		if deployment.Status.Conditions[i].Type == conditionType {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// endpointsReadiness reports whether the owned Service has at least one endpoint address that's
+// actually ready to serve the gRPC port.
+func (r *ComponentReconciler) endpointsReadiness(ctx context.Context, serviceNamespacedName types.NamespacedName) (ready bool, reason, message string, err error) {
+	var reader client.Reader = r.Client
+	if features.Enabled(features.MetadataOnlyWatches) {
+		// EndpointSlice is watched metadata-only in this mode (see SetupWithManager), so the
+		// cached client can't return Endpoints data for it; read straight from the API server.
+		reader = r.APIReader
+	}
+
+	slices := &discoveryv1.EndpointSliceList{}
+	if err := reader.List(ctx, slices,
+		client.InNamespace(serviceNamespacedName.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: serviceNamespacedName.Name},
+	); err != nil {
+		return false, "", "", err
+	}
+
+	for _, slice := range slices.Items {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && *endpoint.Conditions.Ready && len(endpoint.Addresses) > 0 {
+				return true, "EndpointReady", "At least one endpoint is ready", nil
+			}
+		}
+	}
+	return false, "NoReadyEndpoints", "No EndpointSlice address is ready yet", nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	// Bypass the manager's cache for full EndpointSlice reads when MetadataOnlyWatches is on:
+	// controller-runtime shares one cache mode per GVK across every client using it, so once the
+	// watch below goes metadata-only, this is the only way to still read Endpoints data.
+	r.APIReader = mgr.GetAPIReader()
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1alpha1.Component{}).
-		Owns(&corev1.Service{}).
-		Owns(&appsv1.Deployment{}).
-		Complete(r)
+		// Region is a user-authored CRD this operator doesn't label itself, so there's no
+		// labelDomainKey to filter on.
+		Watches(&apiv1alpha1.Region{}, handler.EnqueueRequestsFromMapFunc(r.regionReconciliationRequest)).
+		Owns(&corev1.Service{}, builder.WithPredicates(hasDomainLabelPredicate)).
+		Owns(&appsv1.Deployment{}, builder.WithPredicates(hasDomainLabelPredicate)).
+		Owns(&cmapi.Certificate{}, builder.WithPredicates(hasDomainLabelPredicate))
+
+	if features.Enabled(features.MetadataOnlyWatches) {
+		// EndpointSlices can churn at high frequency and carry no Spec this reconciler needs, so
+		// watch them metadata-only; endpointsReadiness reads the full object via r.APIReader when
+		// it actually needs Endpoints data.
+		bldr = bldr.Watches(&metav1.PartialObjectMetadata{
+			TypeMeta: metav1.TypeMeta{Kind: "EndpointSlice", APIVersion: discoveryv1.SchemeGroupVersion.String()},
+		}, handler.EnqueueRequestsFromMapFunc(r.endpointSliceReconciliationRequest), builder.OnlyMetadata, builder.WithPredicates(hasDomainLabelPredicate))
+	} else {
+		bldr = bldr.Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(r.endpointSliceReconciliationRequest), builder.WithPredicates(hasDomainLabelPredicate))
+	}
+
+	return bldr.Complete(r)
+}
+
+func (r *ComponentReconciler) regionReconciliationRequest(ctx context.Context, obj client.Object) []reconcile.Request {
+	return componentsReferencingRegion(ctx, r.Client, obj)
+}
+
+// endpointSliceReconciliationRequest maps a changed EndpointSlice to the Component owning the
+// Service it belongs to (EndpointSlices are owned by the Service, not the Component directly,
+// so this can't just ride along on Owns(&appsv1.Deployment{})).
+func (r *ComponentReconciler) endpointSliceReconciliationRequest(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	// obj is read through client.Object's interface methods rather than asserted to a concrete
+	// type, since depending on the MetadataOnlyWatches gate (see SetupWithManager) it may be
+	// either a *discoveryv1.EndpointSlice or a *metav1.PartialObjectMetadata.
+
+	serviceName := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		return nil
+	}
+	service := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: obj.GetNamespace()}, service); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to get Service for EndpointSlice", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		}
+		return nil
+	}
+	for _, owner := range service.OwnerReferences {
+		if owner.Kind == "Component" {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: owner.Name, Namespace: service.Namespace}}}
+		}
+	}
+	return nil
+}
+
+// regionsHealthy reports whether the Component's owning Domain's configured regions are
+// healthy. A missing Domain isn't this reconciler's concern (the Domain controller already
+// surfaces that as a dangling-reference problem), so it's treated as "no restriction."
+func (r *ComponentReconciler) regionsHealthy(ctx context.Context, component *apiv1alpha1.Component) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	domain := &apiv1alpha1.Domain{}
+	err := r.Get(ctx, types.NamespacedName{Name: component.Spec.Domain, Namespace: component.Namespace}, domain)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		logger.Error(err, "Failed to get Domain for Component", "namespace", component.Namespace, "name", component.Name)
+		return false, err
+	}
+
+	return regionHealthy(ctx, r.Client, component.Namespace, domain.Spec.Regions)
 }
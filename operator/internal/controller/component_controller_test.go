@@ -3,19 +3,24 @@ package controller
 import (
 	"context"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+	"vimana.host/operator/pkg/features"
 )
 
 var _ = Describe("Component Controller", func() {
@@ -73,28 +78,31 @@ var _ = Describe("Component Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &ComponentReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				APIReader: k8sClient,
 			}
 
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 
 			Expect(err).NotTo(HaveOccurred())
+			// Nothing runs the Deployment/EndpointSlice controllers in this test environment, so
+			// the Deployment never actually rolls out: Available stays False and a retry is scheduled.
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
 
 			// Verify status conditions
 			err = k8sClient.Get(ctx, typeNamespacedName, component)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(component.Status.Conditions).To(HaveLen(1))
-			condition := component.Status.Conditions[0]
-			Expect(condition).To(Equal(metav1.Condition{
-				Type:               "Available",
-				Status:             metav1.ConditionTrue,
-				Reason:             "Reconciled",
-				Message:            "Successfully reconciled component",
-				LastTransitionTime: condition.LastTransitionTime, // non-deterministic
-			}))
+			available := meta.FindStatusCondition(component.Status.Conditions, "Available")
+			Expect(available).NotTo(BeNil())
+			Expect(available.Status).To(Equal(metav1.ConditionFalse))
+			Expect(available.Reason).To(Equal("NotReady"))
+
+			deploymentReady := meta.FindStatusCondition(component.Status.Conditions, "DeploymentReady")
+			Expect(deploymentReady).NotTo(BeNil())
+			Expect(deploymentReady.Status).To(Equal(metav1.ConditionFalse))
 
 			deployments := &appsv1.DeploymentList{}
 			err = k8sClient.List(ctx, deployments)
@@ -185,6 +193,146 @@ var _ = Describe("Component Controller", func() {
 			}))
 		})
 	})
+
+	Context("When the owning Domain restricts to an unhealthy region", func() {
+		const namespace = "default"
+		const resourceName = "region-gated-resource"
+		const domainId = "fedcba9876543210abcdef0123456789"
+		const failureDomain = "aws/us-east-1"
+
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &apiv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: domainId, Namespace: namespace},
+				Spec: apiv1alpha1.DomainSpec{
+					Id:      domainId,
+					Vimana:  "region-gated-vimana",
+					Regions: []string{failureDomain},
+				},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &apiv1alpha1.Region{
+				ObjectMeta: metav1.ObjectMeta{Name: "us-east-1", Namespace: namespace},
+				Spec:       apiv1alpha1.RegionSpec{FailureDomain: failureDomain, Healthy: false},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &apiv1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+				Spec: apiv1alpha1.ComponentSpec{
+					Domain:  domainId,
+					Server:  "some-id",
+					Version: "1.0.0",
+					Image:   "gcr.io/some/image:latest",
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &apiv1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+			})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &apiv1alpha1.Region{
+				ObjectMeta: metav1.ObjectMeta{Name: "us-east-1", Namespace: namespace},
+			})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &apiv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: domainId, Namespace: namespace},
+			})).To(Succeed())
+		})
+
+		It("should not create a Deployment and should report RegionsUnhealthy", func() {
+			controllerReconciler := &ComponentReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), APIReader: k8sClient}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			component := &apiv1alpha1.Component{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, component)).To(Succeed())
+			Expect(component.Status.Conditions).To(HaveLen(1))
+			Expect(component.Status.Conditions[0].Reason).To(Equal("RegionsUnhealthy"))
+
+			deployment := &appsv1.Deployment{}
+			err = k8sClient.Get(ctx, types.NamespacedName{
+				Name:      prefixed(hashed(componentName(domainId, "some-id", "1.0.0")), 'd'),
+				Namespace: namespace,
+			}, deployment)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "Expected no Deployment to be created while the region is unhealthy")
+		})
+	})
+
+	Context("When backend mTLS is enabled", func() {
+		const namespace = "default"
+		const resourceName = "mtls-component"
+		const domainId = "11223344556677889900aabbccddeeff"
+		const serverId = "mtls-server"
+		const version = "1.0.0"
+		const vimanaName = "mtls-vimana"
+
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+		hashedName := hashed(componentName(domainId, serverId, version))
+		certSecretName := prefixed(hashedName, 'm')
+
+		BeforeEach(func() {
+			Expect(features.MutableFeatureGate.(featuregate.MutableFeatureGate).Set("BackendMTLS=true")).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &apiv1alpha1.Vimana{
+				ObjectMeta: metav1.ObjectMeta{Name: vimanaName, Namespace: namespace},
+				Spec: apiv1alpha1.VimanaSpec{
+					BackendCertificateIssuer: cmmeta.ObjectReference{Name: "backend-ca-issuer", Kind: "Issuer"},
+				},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &apiv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: domainId, Namespace: namespace},
+				Spec:       apiv1alpha1.DomainSpec{Id: domainId, Vimana: vimanaName},
+			})).To(Succeed())
+			// Stand in for cert-manager, which doesn't run in this test environment: the Secret a
+			// Certificate resolves to must already exist for reconcileBackendCertificate to mount it.
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: certSecretName, Namespace: namespace},
+				Data:       map[string][]byte{"tls.crt": []byte("stub")},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &apiv1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+				Spec: apiv1alpha1.ComponentSpec{
+					Domain: domainId, Server: serverId, Version: version, Image: "gcr.io/some/image:latest",
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(features.MutableFeatureGate.(featuregate.MutableFeatureGate).Set("BackendMTLS=false")).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &apiv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: certSecretName, Namespace: namespace}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &apiv1alpha1.Domain{ObjectMeta: metav1.ObjectMeta{Name: domainId, Namespace: namespace}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &apiv1alpha1.Vimana{ObjectMeta: metav1.ObjectMeta{Name: vimanaName, Namespace: namespace}})).To(Succeed())
+		})
+
+		It("should provision a backend Certificate and switch the Service and Deployment to encrypted H2", func() {
+			controllerReconciler := &ComponentReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), APIReader: k8sClient}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			cert := &cmapi.Certificate{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: certSecretName, Namespace: namespace}, cert)).To(Succeed())
+			Expect(cert.Spec.SecretName).To(Equal(certSecretName))
+			Expect(cert.Spec.DNSNames).To(Equal([]string{prefixed(hashedName, 's')}))
+			Expect(cert.Spec.IssuerRef).To(Equal(cmmeta.ObjectReference{Name: "backend-ca-issuer", Kind: "Issuer"}))
+
+			service := &corev1.Service{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: prefixed(hashedName, 's'), Namespace: namespace}, service)).To(Succeed())
+			Expect(service.Spec.Ports[0].AppProtocol).To(Equal(ptr.To("kubernetes.io/h2")))
+
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: prefixed(hashedName, 'd'), Namespace: namespace}, deployment)).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+				Name: "backend-tls", MountPath: "/etc/vimana/backend-tls", ReadOnly: true,
+			}))
+			Expect(podSpec.Volumes).To(HaveLen(1))
+			Expect(podSpec.Volumes[0].Secret.SecretName).To(Equal(certSecretName))
+			Expect(deployment.Spec.Template.Annotations).To(HaveKey("vimana.host/backend-cert-resource-version"))
+		})
+	})
 })
 
 func getFirstNonK8sService(services *corev1.ServiceList) *corev1.Service {
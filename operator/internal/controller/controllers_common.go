@@ -13,6 +13,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
 )
 
 const (
@@ -35,6 +38,16 @@ const (
 	grpcPortNumber = 80
 )
 
+// hasDomainLabelPredicate restricts a secondary watch to objects carrying labelDomainKey, which
+// every child resource this operator creates (Deployment, Service, EndpointSlice, GRPCRoute) is
+// labeled with. It doesn't apply to user-authored CRDs (Region, TrafficPolicy), which
+// reference their owning Domain by Spec field rather than by label. This keeps the informer
+// cache for the operator-created kinds from ingesting objects this operator has no stake in.
+var hasDomainLabelPredicate = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	_, ok := obj.GetLabels()[labelDomainKey]
+	return ok
+})
+
 // Extend the interface of a generic K8s object
 // with extra methods to facilitate the operator pattern.
 type ApiResource interface {
@@ -60,6 +73,27 @@ func componentName(domainId, serverId, version string) string {
 	return fmt.Sprintf("%s:%s@%s", domainId, serverId, version)
 }
 
+// registryAuthSecretName is the deterministic name of the dockerconfigjson Secret a Vimana's
+// inline RegistryAuth.Username/Password is materialized into, derived from the Vimana's own
+// name.
+func registryAuthSecretName(vimanaName string) string {
+	return prefixed(hashed(vimanaName), 'p')
+}
+
+// registryPullSecretName resolves the name of the Secret that should be injected as an
+// imagePullSecret on behalf of vimana: the Secret named directly by RegistryAuth.SecretRef, the
+// Secret materialized from RegistryAuth.Username/Password, or "" if RegistryAuth is unset.
+func registryPullSecretName(vimana *apiv1alpha1.Vimana) string {
+	switch {
+	case vimana.Spec.RegistryAuth == nil:
+		return ""
+	case vimana.Spec.RegistryAuth.SecretRef != nil:
+		return vimana.Spec.RegistryAuth.SecretRef.Name
+	default:
+		return registryAuthSecretName(vimana.Name)
+	}
+}
+
 // Return the hex-encoded SHA-224 hash of a string.
 // The result always contains 56 hexadecimal characters.
 func hashed(name string) string {
@@ -0,0 +1,269 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+	"vimana.host/operator/internal/dns"
+)
+
+// dnsFinalizer is held by every Domain whose owning Vimana has DNS configured, so that the
+// records published for it can be removed before the Domain (and the hostnames it names) is
+// actually deleted. DNS records are external resources with no Kubernetes owner reference to
+// garbage-collect them.
+const dnsFinalizer = "vimana.host/dns-records"
+
+// reconcileDns publishes (or, on deletion, removes) the DNS records for domain's canonical
+// hostname and aliases. It's a no-op if the owning Vimana has no Dns configured.
+//
+// For every region domain.Spec.Regions shares with its Vimana (or, if domain.Spec.Regions is
+// empty, every region the Vimana belongs to), a latency/geo record is published pointing at
+// this cluster's own Gateway addresses; other regional Vimana clusters publish their own share
+// of records the same way. If the domain's canonical listener is not Programmed and
+// domain.Spec.Failover is non-empty, every record is instead published as a simple failover
+// record pointing at domain.Spec.Failover.
+func (r *DomainReconciler) reconcileDns(ctx context.Context, domain *apiv1alpha1.Domain) error {
+	logger := log.FromContext(ctx)
+
+	vimana := &apiv1alpha1.Vimana{}
+	if err := r.Get(ctx, types.NamespacedName{Name: domain.Spec.Vimana, Namespace: domain.Namespace}, vimana); err != nil {
+		if apierrors.IsNotFound(err) {
+			// No Vimana yet to carry a Dns configuration: nothing to publish.
+			return nil
+		}
+		logger.Error(err, "Failed to get Vimana for Domain", "namespace", domain.Namespace, "name", domain.Name)
+		return err
+	}
+	if vimana.Spec.Dns == nil {
+		return nil
+	}
+
+	if !domain.DeletionTimestamp.IsZero() {
+		return r.deleteDnsRecords(ctx, domain, vimana)
+	}
+	if !containsString(domain.Finalizers, dnsFinalizer) {
+		domain.Finalizers = append(domain.Finalizers, dnsFinalizer)
+		if err := r.Update(ctx, domain); err != nil {
+			logger.Error(err, "Failed to add DNS finalizer to Domain", "namespace", domain.Namespace, "name", domain.Name)
+			return err
+		}
+	}
+
+	provider, zone, err := r.dnsProvider(ctx, vimana)
+	if err != nil {
+		return err
+	}
+
+	gateway := &gwapi.Gateway{}
+	gatewayNamespacedName := types.NamespacedName{Name: gatewayName(vimana.Name), Namespace: vimana.Namespace}
+	if err := r.Get(ctx, gatewayNamespacedName, gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			// No Gateway yet (e.g. no domains programmed): nothing to publish.
+			return nil
+		}
+		logger.Error(err, "Failed to get Gateway for DNS", "namespace", gatewayNamespacedName.Namespace, "name", gatewayNamespacedName.Name)
+		return err
+	}
+
+	targets := make([]string, 0, len(gateway.Status.Addresses))
+	for _, address := range gateway.Status.Addresses {
+		targets = append(targets, address.Value)
+	}
+
+	healthy := canonicalListenerProgrammed(gateway, domain)
+	inFailover := !healthy && len(domain.Spec.Failover) > 0
+
+	regions := domain.Spec.Regions
+	if len(regions) == 0 {
+		regions = vimana.Spec.Regions
+	}
+	var localRegions []string
+	for _, region := range regions {
+		if containsString(vimana.Spec.Regions, region) {
+			localRegions = append(localRegions, region)
+		}
+	}
+
+	hostnames := append([]string{canonicalDomain(domain.Spec.Id)}, domain.Spec.Aliases...)
+	published := true
+	for _, hostname := range hostnames {
+		records := recordsForHostname(hostname, domain.Spec.Id, targets, localRegions, domain.Spec.Failover, inFailover)
+		for _, record := range records {
+			if err := provider.Upsert(ctx, zone, record); err != nil {
+				logger.Error(err, "Failed to publish DNS record", "hostname", hostname, "region", record.Region)
+				published = false
+			}
+		}
+	}
+
+	return r.updateDnsStatus(ctx, domain, published, healthy, inFailover)
+}
+
+// recordsForHostname returns the Records to publish for 1 hostname: either 1 failover record
+// per failover target, or 1 latency/geo record per region the local cluster is responsible for.
+func recordsForHostname(hostname, setIdentifier string, targets, regions, failover []string, inFailover bool) []dns.Record {
+	if inFailover {
+		return []dns.Record{{
+			Name:          hostname,
+			Type:          "CNAME",
+			Targets:       failover,
+			SetIdentifier: setIdentifier,
+		}}
+	}
+	if len(regions) == 0 {
+		return []dns.Record{{
+			Name:          hostname,
+			Type:          "CNAME",
+			Targets:       targets,
+			SetIdentifier: setIdentifier,
+		}}
+	}
+	records := make([]dns.Record, 0, len(regions))
+	for _, region := range regions {
+		records = append(records, dns.Record{
+			Name:          hostname,
+			Type:          "CNAME",
+			Targets:       targets,
+			SetIdentifier: setIdentifier + "/" + region,
+			Region:        region,
+		})
+	}
+	return records
+}
+
+// deleteDnsRecords removes every record this Domain published, then drops dnsFinalizer so that
+// the Domain's own deletion can proceed. A provider.Delete failure is logged, not returned: like
+// reconcileDns's own Upsert loop, a record the provider can't be reached to clean up must not
+// wedge the Domain (and the hostnames it names) behind a finalizer that can never clear.
+func (r *DomainReconciler) deleteDnsRecords(ctx context.Context, domain *apiv1alpha1.Domain, vimana *apiv1alpha1.Vimana) error {
+	logger := log.FromContext(ctx)
+
+	if !containsString(domain.Finalizers, dnsFinalizer) {
+		return nil
+	}
+
+	provider, zone, err := r.dnsProvider(ctx, vimana)
+	if err != nil {
+		logger.Error(err, "Failed to construct DNS provider for record deletion; removing finalizer anyway", "namespace", domain.Namespace, "name", domain.Name)
+	} else {
+		regions := domain.Spec.Regions
+		if len(regions) == 0 {
+			regions = vimana.Spec.Regions
+		}
+		setIdentifiers := []string{domain.Spec.Id}
+		for _, region := range regions {
+			if containsString(vimana.Spec.Regions, region) {
+				setIdentifiers = append(setIdentifiers, domain.Spec.Id+"/"+region)
+			}
+		}
+		hostnames := append([]string{canonicalDomain(domain.Spec.Id)}, domain.Spec.Aliases...)
+		for _, hostname := range hostnames {
+			for _, setIdentifier := range setIdentifiers {
+				if err := provider.Delete(ctx, zone, dns.Record{Name: hostname, Type: "CNAME", SetIdentifier: setIdentifier}); err != nil {
+					logger.Error(err, "Failed to delete DNS record; removing finalizer anyway", "hostname", hostname)
+				}
+			}
+		}
+	}
+
+	domain.Finalizers = removeString(domain.Finalizers, dnsFinalizer)
+	if err := r.Update(ctx, domain); err != nil {
+		logger.Error(err, "Failed to remove DNS finalizer from Domain", "namespace", domain.Namespace, "name", domain.Name)
+		return err
+	}
+	return nil
+}
+
+// dnsProvider constructs the dns.Provider configured by vimana.Spec.Dns, reading its
+// credentials Secret from the Vimana's own namespace.
+func (r *DomainReconciler) dnsProvider(ctx context.Context, vimana *apiv1alpha1.Vimana) (dns.Provider, string, error) {
+	logger := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	secretNamespacedName := types.NamespacedName{Name: vimana.Spec.Dns.SecretRef.Name, Namespace: vimana.Namespace}
+	if err := r.Get(ctx, secretNamespacedName, secret); err != nil {
+		logger.Error(err, "Failed to get DNS credentials Secret", "namespace", secretNamespacedName.Namespace, "name", secretNamespacedName.Name)
+		return nil, "", err
+	}
+
+	provider, err := dns.New(string(vimana.Spec.Dns.Provider), dns.Credentials(secret.Data))
+	if err != nil {
+		logger.Error(err, "Failed to construct DNS provider", "provider", vimana.Spec.Dns.Provider)
+		return nil, "", err
+	}
+	return provider, vimana.Spec.Dns.Zone, nil
+}
+
+// canonicalListenerProgrammed reports whether the Gateway listener for domain's canonical
+// hostname has a True Programmed condition.
+func canonicalListenerProgrammed(gateway *gwapi.Gateway, domain *apiv1alpha1.Domain) bool {
+	listenerName := gwapi.SectionName("l-" + domainHash(canonicalDomain(domain.Spec.Id)))
+	for _, listenerStatus := range gateway.Status.Listeners {
+		if listenerStatus.Name != listenerName {
+			continue
+		}
+		programmed := meta.FindStatusCondition(listenerStatus.Conditions, string(gwapi.ListenerConditionProgrammed))
+		return programmed != nil && programmed.Status == metav1.ConditionTrue
+	}
+	return false
+}
+
+// updateDnsStatus records the outcome of the most recent DNS reconciliation on the Domain.
+func (r *DomainReconciler) updateDnsStatus(ctx context.Context, domain *apiv1alpha1.Domain, published, healthy, inFailover bool) error {
+	logger := log.FromContext(ctx)
+
+	publishedStatus := metav1.ConditionFalse
+	if published {
+		publishedStatus = metav1.ConditionTrue
+	}
+	healthyStatus := metav1.ConditionFalse
+	if healthy {
+		healthyStatus = metav1.ConditionTrue
+	}
+	inFailoverStatus := metav1.ConditionFalse
+	if inFailover {
+		inFailoverStatus = metav1.ConditionTrue
+	}
+
+	meta.SetStatusCondition(&domain.Status.Conditions, metav1.Condition{
+		Type: "Published", Status: publishedStatus, Reason: "Published", Message: "DNS records reconciled against the provider",
+	})
+	meta.SetStatusCondition(&domain.Status.Conditions, metav1.Condition{
+		Type: "Healthy", Status: healthyStatus, Reason: "ListenerProgrammed", Message: "Canonical Gateway listener Programmed status",
+	})
+	meta.SetStatusCondition(&domain.Status.Conditions, metav1.Condition{
+		Type: "InFailover", Status: inFailoverStatus, Reason: "InFailover", Message: "Whether records currently point at Spec.Failover",
+	})
+	if err := r.Status().Update(ctx, domain); err != nil {
+		logger.Error(err, "Failed to update Domain DNS status", "namespace", domain.Namespace, "name", domain.Name)
+		return err
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
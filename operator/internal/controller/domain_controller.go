@@ -2,22 +2,36 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"path"
 	"reflect"
 
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 
 	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+	"vimana.host/operator/pkg/features"
 )
 
+// featureFlagsConfigMapKey is the Data key under which a domain's feature-flags ConfigMap
+// stores its JSON payload.
+const featureFlagsConfigMapKey = "features.json"
+
 var (
 	// Turn this into a variable so we can take its address.
 	grpcPortNumberForGateway = gwapi.PortNumber(grpcPortNumber)
@@ -27,12 +41,47 @@ var (
 
 	// Make this a variable so that it has an address and we can get a pointer to it.
 	exactMethodMatch = gwapi.GRPCMethodMatchExact
+
+	// Make this a variable so that it has an address and we can get a pointer to it.
+	exactHeaderMatch = gwapi.GRPCHeaderMatchExact
+
+	// gRPC reflection services implicitly served by a Server with Spec.Reflection set; see
+	// ServerSpec.Reflection. firstServerConflict treats these as claimed services too.
+	reflectionServiceNames = []string{
+		"grpc.reflection.v1.ServerReflection",
+		"grpc.reflection.v1alpha1.ServerReflection",
+	}
 )
 
 // DomainReconciler reconciles a Domain object
 type DomainReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// APIReader reads directly from the API server, bypassing the manager's cache. It's needed
+	// for Server, whose watch below is metadata-only when MetadataOnlyWatches is on (see
+	// SetupWithManager), which means the shared cached client can no longer serve full Server
+	// objects for *any* reader.
+	APIReader client.Reader
+}
+
+// grpcBackendRefs builds one weighted GRPCBackendRef per entry of weights, pointing at the
+// Component Service generated for each version of the named server.
+func grpcBackendRefs(domainId, serverId string, weights map[string]int32) []gwapi.GRPCBackendRef {
+	backendRefs := make([]gwapi.GRPCBackendRef, 0, len(weights))
+	for version, weight := range weights {
+		backendRefs = append(backendRefs, gwapi.GRPCBackendRef{
+			BackendRef: gwapi.BackendRef{
+				BackendObjectReference: gwapi.BackendObjectReference{
+					Name: gwapi.ObjectName(prefixed(hashed(componentName(domainId, serverId, version)), 's')),
+					Kind: &serviceKind,
+					Port: &grpcPortNumberForGateway,
+				},
+				Weight: &weight,
+			},
+		})
+	}
+	return backendRefs
 }
 
 // Return true iff the two objects are *not* equal.
@@ -45,6 +94,26 @@ func grpcRouteCopySpec(receiver, giver *gwapi.GRPCRoute) {
 	receiver.Spec = giver.Spec
 }
 
+// Return true iff the two objects' Spec differs.
+func routeRetryFilterSpecDiffers(actual, expected *apiv1alpha1.RouteRetryFilter) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
+}
+
+// Mutate the "spec" value of the receiver to match that of the other object.
+func routeRetryFilterCopySpec(receiver, giver *apiv1alpha1.RouteRetryFilter) {
+	receiver.Spec = giver.Spec
+}
+
+// Return true iff the two objects' Spec differs.
+func routeTimeoutFilterSpecDiffers(actual, expected *apiv1alpha1.RouteTimeoutFilter) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
+}
+
+// Mutate the "spec" value of the receiver to match that of the other object.
+func routeTimeoutFilterCopySpec(receiver, giver *apiv1alpha1.RouteTimeoutFilter) {
+	receiver.Spec = giver.Spec
+}
+
 // +kubebuilder:rbac:groups=api.vimana.host,resources=domains,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=api.vimana.host,resources=domains/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=api.vimana.host,resources=domains/finalizers,verbs=update
@@ -69,14 +138,35 @@ func (r *DomainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	if err = r.reconcileDns(ctx, domain); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !domain.DeletionTimestamp.IsZero() {
+		// Only the DNS records (an external resource) need explicit cleanup on deletion;
+		// every other resource this controller manages is owned and thus garbage-collected.
+		return ctrl.Result{}, nil
+	}
+
+	if err = r.updateRegionsResolvedStatus(ctx, domain); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// List all the servers under the domain.
 	servers := &apiv1alpha1.ServerList{}
-	err = r.List(ctx, servers, client.InNamespace(req.Namespace), client.MatchingLabels{labelDomainKey: domain.Spec.Id})
+	err = r.serverReader().List(ctx, servers, client.InNamespace(req.Namespace), client.MatchingLabels{labelDomainKey: domain.Spec.Id})
 	if err != nil {
 		logger.Error(err, "Failed to list Servers", "namespace", req.Namespace, "domain", domain.Spec.Id)
 		return ctrl.Result{}, err
 	}
 
+	if err = r.updateDomainConflictStatus(ctx, domain, servers.Items); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.reconcileFeatureFlagsConfigMap(ctx, domain, servers.Items); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	hostnames := make([]gwapi.Hostname, 0, len(domain.Spec.Aliases)+1)
 	hostnames = append(hostnames, gwapi.Hostname(canonicalDomain(domain.Spec.Id)))
 	for _, alias := range domain.Spec.Aliases {
@@ -95,24 +185,13 @@ func (r *DomainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			})
 		}
 
-		backendRefs := make([]gwapi.GRPCBackendRef, 0, len(server.Spec.VersionWeights))
-		for version, weight := range server.Spec.VersionWeights {
-			backendRefs = append(backendRefs, gwapi.GRPCBackendRef{
-				BackendRef: gwapi.BackendRef{
-					BackendObjectReference: gwapi.BackendObjectReference{
-						Name: gwapi.ObjectName(prefixed(hashed(componentName(domain.Spec.Id, server.Spec.Id, version)), 's')),
-						Kind: &serviceKind,
-						Port: &grpcPortNumberForGateway,
-					},
-					Weight: &weight,
-				},
-			})
-		}
+		backendRefs := grpcBackendRefs(domain.Spec.Id, server.Spec.Id, server.Spec.VersionWeights)
 
-		rules = append(rules, gwapi.GRPCRouteRule{
-			Matches:     matches,
-			BackendRefs: backendRefs,
-		})
+		serverRules, err := r.trafficPolicyRules(ctx, domain, &server, matches, backendRefs)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		rules = append(rules, serverRules...)
 	}
 
 	grpcRouteNamespacedName := types.NamespacedName{
@@ -153,27 +232,703 @@ func (r *DomainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	if err = r.reconcileHttpRoute(ctx, domain, servers.Items, hostnames); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.reconcileBackendTlsPolicies(ctx, domain, servers.Items); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// reconcileHttpRoute generates an HTTPRoute alongside the GRPCRoute when Domain.Spec.OpenApi is
+// set, so that Servers with a REST-transcoded surface (ServerSpec.OpenApiSchemaRef) are also
+// reachable over HTTP. It mirrors the GRPCRoute's parent gateway and hostnames; its rules are
+// derived per-Server from that Server's OpenAPI 3 document, 1 rule per (path, method) operation,
+// routed to the same weighted backends as the GRPCRoute. The HTTPRoute is removed instead,
+// whenever OpenApi is unset or no Server in the domain has an OpenApiSchemaRef.
+func (r *DomainReconciler) reconcileHttpRoute(ctx context.Context, domain *apiv1alpha1.Domain, servers []apiv1alpha1.Server, hostnames []gwapi.Hostname) error {
+	namespacedName := types.NamespacedName{Name: domain.Spec.Id, Namespace: domain.Namespace}
+	if !domain.Spec.OpenApi {
+		return ensureResourceDeleted(r.Client, ctx, namespacedName, &gwapi.HTTPRoute{})
+	}
+
+	var rules []gwapi.HTTPRouteRule
+	for _, server := range servers {
+		if server.Spec.OpenApiSchemaRef == nil {
+			continue
+		}
+		doc, err := fetchOpenApiSchema(ctx, r.Client, server.Namespace, server.Spec.OpenApiSchemaRef)
+		if err != nil {
+			return fmt.Errorf("domain %q, server %q: %w", domain.Spec.Id, server.Spec.Id, err)
+		}
+		backendRefs := httpBackendRefs(domain.Spec.Id, server.Spec.Id, server.Spec.VersionWeights)
+		for _, match := range httpRouteMatchesFromOpenApi(doc) {
+			rules = append(rules, gwapi.HTTPRouteRule{
+				Matches:     []gwapi.HTTPRouteMatch{match},
+				BackendRefs: backendRefs,
+			})
+		}
+	}
+	if len(rules) == 0 {
+		return ensureResourceDeleted(r.Client, ctx, namespacedName, &gwapi.HTTPRoute{})
+	}
+
+	expected := &gwapi.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespacedName.Name,
+			Namespace: namespacedName.Namespace,
+			Labels: map[string]string{
+				labelDomainKey: domain.Spec.Id,
+			},
+		},
+		Spec: gwapi.HTTPRouteSpec{
+			CommonRouteSpec: gwapi.CommonRouteSpec{
+				ParentRefs: []gwapi.ParentReference{
+					{
+						Name: gwapi.ObjectName(gatewayName(domain.Spec.Vimana)),
+					},
+				},
+			},
+			Hostnames: hostnames,
+			Rules:     rules,
+		},
+	}
+
+	if err := ctrl.SetControllerReference(domain, expected, r.Scheme); err != nil {
+		return err
+	}
+	return ensureManagedResource(r.Client, ctx, namespacedName, &gwapi.HTTPRoute{}, expected)
+}
+
+// Return true iff the two objects' Spec differs.
+func backendTlsPolicySpecDiffers(actual, expected *gwapiv1alpha3.BackendTLSPolicy) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
+}
+
+// Mutate the "spec" value of the receiver to match that of the other object.
+func backendTlsPolicyCopySpec(receiver, giver *gwapiv1alpha3.BackendTLSPolicy) {
+	receiver.Spec = giver.Spec
+}
+
+// reconcileBackendTlsPolicies ensures a BackendTLSPolicy validates each Server version's
+// generated Service once backend mTLS is configured (see features.BackendMTLS and
+// VimanaSpec.BackendCertificateIssuer/BackendCertificateCABundle), so the gateway verifies the
+// backend's certificate SAN before trusting the encrypted H2 connection
+// ComponentReconciler.reconcileBackendCertificate switches the Service to. Every
+// previously-managed BackendTLSPolicy under domain is removed instead, whenever mTLS isn't (or
+// is no longer) configured.
+func (r *DomainReconciler) reconcileBackendTlsPolicies(ctx context.Context, domain *apiv1alpha1.Domain, servers []apiv1alpha1.Server) error {
+	logger := log.FromContext(ctx)
+
+	existing := &gwapiv1alpha3.BackendTLSPolicyList{}
+	if err := r.List(ctx, existing, client.InNamespace(domain.Namespace), client.MatchingLabels{labelDomainKey: domain.Spec.Id}); err != nil {
+		logger.Error(err, "Failed to list BackendTLSPolicies", "domain", domain.Spec.Id)
+		return err
+	}
+
+	wanted, err := r.wantedBackendTlsPolicies(ctx, domain, servers)
+	if err != nil {
+		return err
+	}
+
+	for name, expected := range wanted {
+		namespacedName := types.NamespacedName{Name: name, Namespace: domain.Namespace}
+		if err := ctrl.SetControllerReference(domain, expected, r.Scheme); err != nil {
+			return err
+		}
+		if err := ensureResourceHasSpecAndLabels(r.Client, ctx, namespacedName, &gwapiv1alpha3.BackendTLSPolicy{}, expected, backendTlsPolicySpecDiffers, backendTlsPolicyCopySpec); err != nil {
+			return err
+		}
+	}
+
+	for i := range existing.Items {
+		policy := &existing.Items[i]
+		if _, ok := wanted[policy.Name]; ok {
+			continue
+		}
+		if err := r.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete stale BackendTLSPolicy", "namespace", policy.Namespace, "name", policy.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// wantedBackendTlsPolicies returns the BackendTLSPolicy wanted for every (Server, version) pair
+// under domain, keyed by name, or an empty map if features.BackendMTLS is off or the owning
+// Vimana has no BackendCertificateIssuer/BackendCertificateCABundle configured.
+func (r *DomainReconciler) wantedBackendTlsPolicies(ctx context.Context, domain *apiv1alpha1.Domain, servers []apiv1alpha1.Server) (map[string]*gwapiv1alpha3.BackendTLSPolicy, error) {
+	logger := log.FromContext(ctx)
+
+	if !features.Enabled(features.BackendMTLS) {
+		return nil, nil
+	}
+
+	vimana := &apiv1alpha1.Vimana{}
+	if err := r.Get(ctx, types.NamespacedName{Name: domain.Spec.Vimana, Namespace: domain.Namespace}, vimana); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Error(err, "Failed to get Vimana for Domain", "namespace", domain.Namespace, "name", domain.Spec.Id)
+		return nil, err
+	}
+	if vimana.Spec.BackendCertificateIssuer == (cmmeta.ObjectReference{}) || vimana.Spec.BackendCertificateCABundle == nil {
+		return nil, nil
+	}
+	caCertificateRefs := []gwapi.LocalObjectReference{
+		{
+			Kind: "ConfigMap",
+			Name: gwapi.ObjectName(vimana.Spec.BackendCertificateCABundle.Name),
+		},
+	}
+
+	wanted := map[string]*gwapiv1alpha3.BackendTLSPolicy{}
+	for _, server := range servers {
+		for version := range server.Spec.VersionWeights {
+			serviceName := prefixed(hashed(componentName(domain.Spec.Id, server.Spec.Id, version)), 's')
+			name := prefixed(hashed(fmt.Sprintf("%s/%s", domain.Namespace, serviceName)), 'b')
+			wanted[name] = &gwapiv1alpha3.BackendTLSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: domain.Namespace,
+					Labels:    map[string]string{labelDomainKey: domain.Spec.Id},
+				},
+				Spec: gwapiv1alpha3.BackendTLSPolicySpec{
+					TargetRefs: []gwapiv1alpha2.LocalPolicyTargetReference{
+						{Kind: "Service", Name: gwapiv1alpha2.ObjectName(serviceName)},
+					},
+					Validation: gwapiv1alpha3.BackendTLSPolicyValidation{
+						CACertificateRefs: caCertificateRefs,
+						Hostname:          gwapi.PreciseHostname(serviceName),
+					},
+				},
+			}
+		}
+	}
+	return wanted, nil
+}
+
+// reconcileFeatureFlagsConfigMap projects every server's Spec.Features (see FeatureFlag) into a
+// single ConfigMap owned by domain, keyed by Server.Spec.Id, so that Component pods can mount
+// it and evaluate flags locally (via pkg/flags) without querying the K8s API on every request.
+// If no server under the domain defines any flags, the ConfigMap (if any) is removed instead.
+func (r *DomainReconciler) reconcileFeatureFlagsConfigMap(ctx context.Context, domain *apiv1alpha1.Domain, servers []apiv1alpha1.Server) error {
+	namespacedName := types.NamespacedName{
+		Name:      prefixed(hashed(domain.Spec.Id), 'f'),
+		Namespace: domain.Namespace,
+	}
+
+	byServerId := make(map[string]map[string]apiv1alpha1.FeatureFlag, len(servers))
+	for _, server := range servers {
+		if len(server.Spec.Features) > 0 {
+			byServerId[server.Spec.Id] = server.Spec.Features
+		}
+	}
+	if len(byServerId) == 0 {
+		return ensureResourceDeleted(r.Client, ctx, namespacedName, &corev1.ConfigMap{})
+	}
+
+	encoded, err := json.Marshal(byServerId)
+	if err != nil {
+		return fmt.Errorf("marshaling feature flags for domain %q: %w", domain.Spec.Id, err)
+	}
+
+	expected := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespacedName.Name,
+			Namespace: namespacedName.Namespace,
+			Labels:    map[string]string{labelDomainKey: domain.Spec.Id},
+		},
+		Data: map[string]string{featureFlagsConfigMapKey: string(encoded)},
+	}
+	if err := ctrl.SetControllerReference(domain, expected, r.Scheme); err != nil {
+		return err
+	}
+	return ensureManagedResource(r.Client, ctx, namespacedName, &corev1.ConfigMap{}, expected)
+}
+
+// updateRegionsResolvedStatus resolves domain.Spec.Regions against the live Region objects in
+// its namespace and records a RegionsResolved condition reflecting the outcome: True iff every
+// named region matched a live Region, False with reason UnknownRegion listing the names that
+// didn't. A Domain with no Spec.Regions has nothing to resolve and is vacuously True.
+func (r *DomainReconciler) updateRegionsResolvedStatus(ctx context.Context, domain *apiv1alpha1.Domain) error {
+	logger := log.FromContext(ctx)
+
+	_, unresolved, err := resolveRegions(ctx, r.Client, domain.Namespace, domain.Spec.Regions)
+	if err != nil {
+		return err
+	}
+
+	status, reason, message := metav1.ConditionTrue, "Resolved", "Every region resolved to a live Region"
+	if len(unresolved) > 0 {
+		status, reason, message = metav1.ConditionFalse, "UnknownRegion", fmt.Sprintf("No live Region matches: %v", unresolved)
+	}
+	meta.SetStatusCondition(&domain.Status.Conditions, metav1.Condition{
+		Type: "RegionsResolved", Status: status, Reason: reason, Message: message,
+	})
+	if err := r.Status().Update(ctx, domain); err != nil {
+		logger.Error(err, "Failed to update Domain RegionsResolved status", "namespace", domain.Namespace, "name", domain.Name)
+		return err
+	}
+	return nil
+}
+
+// updateDomainConflictStatus records a DomainConflict condition over servers (the domain's
+// Servers, as already listed by Reconcile): False if any 2 collide on Spec.Id or on a claimed
+// gRPC service, True otherwise. This exists because the Server validating webhook only sees 1
+// Server at a time out of a cached list, so 2 Creates racing each other can both pass it; this
+// reconciler sees every Server under the domain together and so always catches what slips
+// through.
+func (r *DomainReconciler) updateDomainConflictStatus(ctx context.Context, domain *apiv1alpha1.Domain, servers []apiv1alpha1.Server) error {
+	logger := log.FromContext(ctx)
+
+	status, reason, message := metav1.ConditionTrue, "NoConflict", "No Server in the domain conflicts with another"
+	if conflict := firstServerConflict(servers); conflict != "" {
+		status, reason, message = metav1.ConditionFalse, "ServiceOrIdConflict", conflict
+	}
+	meta.SetStatusCondition(&domain.Status.Conditions, metav1.Condition{
+		Type: "DomainConflict", Status: status, Reason: reason, Message: message,
+	})
+	if err := r.Status().Update(ctx, domain); err != nil {
+		logger.Error(err, "Failed to update Domain DomainConflict status", "namespace", domain.Namespace, "name", domain.Name)
+		return err
+	}
+	return nil
+}
+
+// firstServerConflict returns a message describing the first Spec.Id or claimed-service
+// collision found among servers, or "" if there is none. A Server's claimed services are its
+// Spec.Services, plus the 2 gRPC reflection services if Spec.Reflection is set.
+func firstServerConflict(servers []apiv1alpha1.Server) string {
+	seenIds := make(map[string]string, len(servers))
+	seenServices := make(map[string]string, len(servers))
+	for _, server := range servers {
+		if owner, ok := seenIds[server.Spec.Id]; ok {
+			return fmt.Sprintf("Servers %q and %q both claim id %q", owner, server.Name, server.Spec.Id)
+		}
+		seenIds[server.Spec.Id] = server.Name
+
+		services := server.Spec.Services
+		if server.Spec.Reflection {
+			services = append(append([]string{}, services...), reflectionServiceNames...)
+		}
+		for _, service := range services {
+			if owner, ok := seenServices[service]; ok {
+				return fmt.Sprintf("Servers %q and %q both claim service %q", owner, server.Name, service)
+			}
+			seenServices[service] = server.Name
+		}
+	}
+	return ""
+}
+
+// trafficPolicyRules applies the namespace's TrafficPolicy objects targeting server to the base
+// GRPCRouteRule described by matches/backendRefs, returning the (possibly several) rules to emit
+// in its place. Services not covered by any TrafficPolicy fall back to the base rule unchanged.
+// Gateway API evaluates Rules in order and uses the first match, so any header-match rules are
+// always emitted ahead of the rule(s) they augment.
+func (r *DomainReconciler) trafficPolicyRules(
+	ctx context.Context,
+	domain *apiv1alpha1.Domain,
+	server *apiv1alpha1.Server,
+	matches []gwapi.GRPCRouteMatch,
+	backendRefs []gwapi.GRPCBackendRef,
+) ([]gwapi.GRPCRouteRule, error) {
+	logger := log.FromContext(ctx)
+
+	if !features.Enabled(features.TrafficPolicyV1) {
+		return r.baseRule(ctx, domain, server, matches, backendRefs)
+	}
+
+	policies := &apiv1alpha1.TrafficPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(domain.Namespace)); err != nil {
+		logger.Error(err, "Failed to list TrafficPolicies", "namespace", domain.Namespace)
+		return nil, err
+	}
+
+	var applicable []apiv1alpha1.TrafficPolicy
+	for _, policy := range policies.Items {
+		if policy.Spec.Server == server.Spec.Id {
+			applicable = append(applicable, policy)
+		}
+	}
+	if len(applicable) == 0 {
+		return r.baseRule(ctx, domain, server, matches, backendRefs)
+	}
+
+	var rules []gwapi.GRPCRouteRule
+	covered := map[string]bool{}
+	for _, policy := range applicable {
+		policyMatches := matchesForPolicy(policy, matches)
+		if len(policyMatches) == 0 {
+			continue
+		}
+		for _, match := range policyMatches {
+			covered[*match.Method.Service] = true
+		}
+
+		for _, headerRule := range policy.Spec.HeaderMatch {
+			rules = append(rules, gwapi.GRPCRouteRule{
+				Matches:     headerMatches(policyMatches, headerRule),
+				BackendRefs: grpcBackendRefs(domain.Spec.Id, server.Spec.Id, headerRule.VersionWeights),
+			})
+		}
+
+		retry := effectiveRetry(domain, server, policy.Spec.Retry)
+		timeout := effectiveTimeout(domain, server, policy.Spec.Timeout)
+		filters, err := r.routeFilters(ctx, domain, policy.Name, server.Spec.Id, retry, timeout, policy.Spec.Mirror)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, gwapi.GRPCRouteRule{
+			Matches:     policyMatches,
+			BackendRefs: backendRefs,
+			Timeouts:    gatewayTimeouts(timeout),
+			Filters:     filters,
+		})
+	}
+
+	var uncovered []gwapi.GRPCRouteMatch
+	for _, match := range matches {
+		if !covered[*match.Method.Service] {
+			uncovered = append(uncovered, match)
+		}
+	}
+	if len(uncovered) == 0 {
+		return rules, nil
+	}
+	base, err := r.baseRule(ctx, domain, server, uncovered, backendRefs)
+	return append(rules, base...), err
+}
+
+// baseRule returns the single rule to emit for a server's services not covered by any applicable
+// TrafficPolicy (or when TrafficPolicyV1 is disabled), still honoring any Domain/Server-level
+// Retry/Timeout defaults.
+func (r *DomainReconciler) baseRule(
+	ctx context.Context,
+	domain *apiv1alpha1.Domain,
+	server *apiv1alpha1.Server,
+	matches []gwapi.GRPCRouteMatch,
+	backendRefs []gwapi.GRPCBackendRef,
+) ([]gwapi.GRPCRouteRule, error) {
+	retry := effectiveRetry(domain, server, nil)
+	timeout := effectiveTimeout(domain, server, nil)
+	filters, err := r.routeFilters(ctx, domain, fmt.Sprintf("%s/%s", domain.Spec.Id, server.Spec.Id), server.Spec.Id, retry, timeout, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []gwapi.GRPCRouteRule{{
+		Matches:     matches,
+		BackendRefs: backendRefs,
+		Timeouts:    gatewayTimeouts(timeout),
+		Filters:     filters,
+	}}, nil
+}
+
+// effectiveRetry returns the most specific of policyRetry, server.Spec.Retry, and
+// domain.Spec.Retry (in that order), or nil if none of them set one.
+func effectiveRetry(domain *apiv1alpha1.Domain, server *apiv1alpha1.Server, policyRetry *apiv1alpha1.RetryPolicy) *apiv1alpha1.RetryPolicy {
+	if policyRetry != nil {
+		return policyRetry
+	}
+	if server.Spec.Retry != nil {
+		return server.Spec.Retry
+	}
+	return domain.Spec.Retry
+}
+
+// effectiveTimeout merges the Domain/Server-level Timeout defaults with a TrafficPolicy's own
+// (narrower) Timeout override, which takes precedence for the request timeout specifically;
+// BackendRequest always comes from the Server/Domain default, since TrafficPolicy has no
+// equivalent field.
+func effectiveTimeout(domain *apiv1alpha1.Domain, server *apiv1alpha1.Server, policyTimeout *metav1.Duration) *apiv1alpha1.TimeoutPolicy {
+	timeout := server.Spec.Timeout
+	if timeout == nil {
+		timeout = domain.Spec.Timeout
+	}
+	if policyTimeout == nil {
+		return timeout
+	}
+	merged := &apiv1alpha1.TimeoutPolicy{Request: policyTimeout}
+	if timeout != nil {
+		merged.BackendRequest = timeout.BackendRequest
+	}
+	return merged
+}
+
+// matchesForPolicy narrows matches down to the services covered by policy's Service glob
+// (every service, if unset).
+func matchesForPolicy(policy apiv1alpha1.TrafficPolicy, matches []gwapi.GRPCRouteMatch) []gwapi.GRPCRouteMatch {
+	if policy.Spec.Service == "" {
+		return matches
+	}
+	var narrowed []gwapi.GRPCRouteMatch
+	for _, match := range matches {
+		if match.Method != nil && match.Method.Service != nil {
+			if ok, _ := path.Match(policy.Spec.Service, *match.Method.Service); ok {
+				narrowed = append(narrowed, match)
+			}
+		}
+	}
+	return narrowed
+}
+
+// headerMatches returns a copy of matches with an additional exact-match header requirement,
+// used to express a HeaderMatchRule's canary override.
+func headerMatches(matches []gwapi.GRPCRouteMatch, rule apiv1alpha1.HeaderMatchRule) []gwapi.GRPCRouteMatch {
+	withHeader := make([]gwapi.GRPCRouteMatch, len(matches))
+	for i, match := range matches {
+		withHeader[i] = match
+		withHeader[i].Headers = []gwapi.GRPCHeaderMatch{
+			{
+				Type:  &exactHeaderMatch,
+				Name:  gwapi.GRPCHeaderName(rule.Name),
+				Value: rule.Value,
+			},
+		}
+	}
+	return withHeader
+}
+
+// gatewayTimeouts translates an effective TimeoutPolicy into Gateway API's native per-rule
+// timeout.
+func gatewayTimeouts(timeout *apiv1alpha1.TimeoutPolicy) *gwapi.HTTPRouteTimeouts {
+	if timeout == nil {
+		return nil
+	}
+	var out gwapi.HTTPRouteTimeouts
+	if timeout.Request != nil {
+		request := gwapi.Duration(timeout.Request.Duration.String())
+		out.Request = &request
+	}
+	if timeout.BackendRequest != nil {
+		backendRequest := gwapi.Duration(timeout.BackendRequest.Duration.String())
+		out.BackendRequest = &backendRequest
+	}
+	if out.Request == nil && out.BackendRequest == nil {
+		return nil
+	}
+	return &out
+}
+
+// routeFilters reconciles the RouteRetryFilter/RouteTimeoutFilter CRs implied by retry/timeout
+// (deleting either that no longer applies) and returns the GRPCRouteFilters referencing them via
+// ExtensionRef, alongside a RequestMirror filter for mirror if set. Gateway API has no native
+// retry filter (and not every implementation honors its native rules[].timeouts either), so both
+// are expressed as implementation-specific extensions, following the pattern of Consul API
+// Gateway's RouteRetryFilter/RouteTimeoutFilter CRDs. name must be stable and unique to the
+// TrafficPolicy (or Server, for the no-policy default) retry/timeout was derived from, since it
+// names the generated CRs.
+func (r *DomainReconciler) routeFilters(
+	ctx context.Context,
+	domain *apiv1alpha1.Domain,
+	name, serverId string,
+	retry *apiv1alpha1.RetryPolicy,
+	timeout *apiv1alpha1.TimeoutPolicy,
+	mirror *apiv1alpha1.MirrorPolicy,
+) ([]gwapi.GRPCRouteFilter, error) {
+	var filters []gwapi.GRPCRouteFilter
+
+	retryFilterName, err := r.reconcileRouteRetryFilter(ctx, domain, name, retry)
+	if err != nil {
+		return nil, err
+	}
+	if retryFilterName != "" {
+		filters = append(filters, gwapi.GRPCRouteFilter{
+			Type: gwapi.GRPCRouteFilterExtensionRef,
+			ExtensionRef: &gwapi.LocalObjectReference{
+				Group: gwapi.Group(apiv1alpha1.GroupVersion.Group),
+				Kind:  "RouteRetryFilter",
+				Name:  gwapi.ObjectName(retryFilterName),
+			},
+		})
+	}
+
+	timeoutFilterName, err := r.reconcileRouteTimeoutFilter(ctx, domain, name, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if timeoutFilterName != "" {
+		filters = append(filters, gwapi.GRPCRouteFilter{
+			Type: gwapi.GRPCRouteFilterExtensionRef,
+			ExtensionRef: &gwapi.LocalObjectReference{
+				Group: gwapi.Group(apiv1alpha1.GroupVersion.Group),
+				Kind:  "RouteTimeoutFilter",
+				Name:  gwapi.ObjectName(timeoutFilterName),
+			},
+		})
+	}
+
+	if mirror != nil {
+		mirrorServerId := mirror.Server
+		if mirrorServerId == "" {
+			mirrorServerId = serverId
+		}
+		percent := mirror.Percent
+		filters = append(filters, gwapi.GRPCRouteFilter{
+			Type: gwapi.GRPCRouteFilterRequestMirror,
+			RequestMirror: &gwapi.HTTPRequestMirrorFilter{
+				BackendRef: gwapi.BackendObjectReference{
+					Name: gwapi.ObjectName(prefixed(hashed(componentName(domain.Spec.Id, mirrorServerId, mirror.Version)), 's')),
+					Kind: &serviceKind,
+					Port: &grpcPortNumberForGateway,
+				},
+				Percent: &percent,
+			},
+		})
+	}
+
+	return filters, nil
+}
+
+// reconcileRouteRetryFilter ensures a RouteRetryFilter named after name reflects retry, or
+// deletes it if retry is nil. Returns the resource's name, or "" if none exists.
+func (r *DomainReconciler) reconcileRouteRetryFilter(ctx context.Context, domain *apiv1alpha1.Domain, name string, retry *apiv1alpha1.RetryPolicy) (string, error) {
+	resourceName := prefixed(hashed(name), 'r')
+	namespacedName := types.NamespacedName{Name: resourceName, Namespace: domain.Namespace}
+	if retry == nil {
+		return "", ensureResourceDeleted(r.Client, ctx, namespacedName, &apiv1alpha1.RouteRetryFilter{})
+	}
+
+	expected := &apiv1alpha1.RouteRetryFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespacedName.Name,
+			Namespace: namespacedName.Namespace,
+			Labels:    map[string]string{labelDomainKey: domain.Spec.Id},
+		},
+		Spec: apiv1alpha1.RouteRetryFilterSpec{
+			Attempts:      retry.Attempts,
+			PerTryTimeout: retry.PerTryTimeout,
+			RetryOn:       retry.RetryOn,
+			BackoffBase:   retry.BackoffBase,
+			BackoffMax:    retry.BackoffMax,
+		},
+	}
+	if err := ctrl.SetControllerReference(domain, expected, r.Scheme); err != nil {
+		return "", err
+	}
+	if err := ensureResourceHasSpecAndLabels(r.Client, ctx, namespacedName, &apiv1alpha1.RouteRetryFilter{}, expected, routeRetryFilterSpecDiffers, routeRetryFilterCopySpec); err != nil {
+		return "", err
+	}
+	return resourceName, nil
+}
+
+// reconcileRouteTimeoutFilter ensures a RouteTimeoutFilter named after name reflects timeout, or
+// deletes it if timeout is nil. Returns the resource's name, or "" if none exists.
+func (r *DomainReconciler) reconcileRouteTimeoutFilter(ctx context.Context, domain *apiv1alpha1.Domain, name string, timeout *apiv1alpha1.TimeoutPolicy) (string, error) {
+	resourceName := prefixed(hashed(name), 't')
+	namespacedName := types.NamespacedName{Name: resourceName, Namespace: domain.Namespace}
+	if timeout == nil {
+		return "", ensureResourceDeleted(r.Client, ctx, namespacedName, &apiv1alpha1.RouteTimeoutFilter{})
+	}
+
+	expected := &apiv1alpha1.RouteTimeoutFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespacedName.Name,
+			Namespace: namespacedName.Namespace,
+			Labels:    map[string]string{labelDomainKey: domain.Spec.Id},
+		},
+		Spec: apiv1alpha1.RouteTimeoutFilterSpec{
+			Request:        timeout.Request,
+			BackendRequest: timeout.BackendRequest,
+		},
+	}
+	if err := ctrl.SetControllerReference(domain, expected, r.Scheme); err != nil {
+		return "", err
+	}
+	if err := ensureResourceHasSpecAndLabels(r.Client, ctx, namespacedName, &apiv1alpha1.RouteTimeoutFilter{}, expected, routeTimeoutFilterSpecDiffers, routeTimeoutFilterCopySpec); err != nil {
+		return "", err
+	}
+	return resourceName, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DomainReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	// Bypass the manager's cache for full Server reads when MetadataOnlyWatches is on: once the
+	// watch below goes metadata-only, the cache's informer for the Server GVK can no longer
+	// serve full objects to any client (see serverReader).
+	r.APIReader = mgr.GetAPIReader()
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1alpha1.Domain{}).
-		Watches(&apiv1alpha1.Server{}, handler.EnqueueRequestsFromMapFunc(r.serverReconciliationRequest)).
-		Owns(&gwapi.GRPCRoute{}).
-		Complete(r)
+		// Region and TrafficPolicy are user-authored CRDs that reference their owning Domain by
+		// a Spec field rather than by label, so hasDomainLabelPredicate doesn't apply.
+		Watches(&apiv1alpha1.Region{}, handler.EnqueueRequestsFromMapFunc(r.regionReconciliationRequest)).
+		Watches(&apiv1alpha1.TrafficPolicy{}, handler.EnqueueRequestsFromMapFunc(r.trafficPolicyReconciliationRequest)).
+		Owns(&gwapi.GRPCRoute{}, builder.WithPredicates(hasDomainLabelPredicate)).
+		Owns(&gwapi.HTTPRoute{}, builder.WithPredicates(hasDomainLabelPredicate)).
+		Owns(&corev1.ConfigMap{}, builder.WithPredicates(hasDomainLabelPredicate)).
+		Owns(&apiv1alpha1.RouteRetryFilter{}, builder.WithPredicates(hasDomainLabelPredicate)).
+		Owns(&apiv1alpha1.RouteTimeoutFilter{}, builder.WithPredicates(hasDomainLabelPredicate)).
+		Owns(&gwapiv1alpha3.BackendTLSPolicy{}, builder.WithPredicates(hasDomainLabelPredicate))
+
+	if features.Enabled(features.MetadataOnlyWatches) {
+		// All this controller needs from the watch itself is labelDomainKey, to map a changed
+		// Server to its Domain; the Spec fields it actually renders into the GRPCRoute are read
+		// separately via serverReader. Servers can number in the thousands per Domain, so keeping
+		// their watch metadata-only meaningfully shrinks this controller's cache.
+		bldr = bldr.Watches(&metav1.PartialObjectMetadata{
+			TypeMeta: metav1.TypeMeta{Kind: "Server", APIVersion: apiv1alpha1.GroupVersion.String()},
+		}, handler.EnqueueRequestsFromMapFunc(r.serverReconciliationRequest), builder.OnlyMetadata)
+	} else {
+		bldr = bldr.Watches(&apiv1alpha1.Server{}, handler.EnqueueRequestsFromMapFunc(r.serverReconciliationRequest))
+	}
+
+	return bldr.Complete(r)
+}
+
+// serverReader returns the client.Reader to use for full (Spec-bearing) Server reads: the
+// manager's cache, unless MetadataOnlyWatches has put the Server GVK's informer into
+// metadata-only mode, in which case only r.APIReader can still serve full objects.
+func (r *DomainReconciler) serverReader() client.Reader {
+	if features.Enabled(features.MetadataOnlyWatches) {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// trafficPolicyReconciliationRequest maps a changed TrafficPolicy to the Domain of the Server it
+// targets, so that policy changes re-render the GRPCRoute.
+func (r *DomainReconciler) trafficPolicyReconciliationRequest(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	policy := obj.(*apiv1alpha1.TrafficPolicy)
+
+	servers := &apiv1alpha1.ServerList{}
+	if err := r.serverReader().List(ctx, servers, client.InNamespace(policy.Namespace)); err != nil {
+		logger.Error(err, "Failed to list Servers for TrafficPolicy", "namespace", policy.Namespace, "name", policy.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, server := range servers.Items {
+		if server.Spec.Id == policy.Spec.Server {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: server.Spec.Domain, Namespace: policy.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+func (r *DomainReconciler) regionReconciliationRequest(ctx context.Context, obj client.Object) []reconcile.Request {
+	return domainsReferencingRegion(ctx, r.Client, obj)
 }
 
 func (r *DomainReconciler) serverReconciliationRequest(ctx context.Context, obj client.Object) []reconcile.Request {
 	logger := log.FromContext(ctx)
-	server := obj.(*apiv1alpha1.Server)
+	// obj is read through client.Object's interface methods rather than asserted to a concrete
+	// type, since depending on the MetadataOnlyWatches gate (see SetupWithManager) it may be
+	// either a *apiv1alpha1.Server or a *metav1.PartialObjectMetadata.
 
-	domainId := server.Labels[labelDomainKey]
+	domainId := obj.GetLabels()[labelDomainKey]
 	if domainId == "" {
 		// The server resource has no domain label (an invariant has been violated).
 		// Hopefully this never happens.
-		logger.Error(nil, "Server lacks a domain label", "namespace", server.Namespace, "name", server.Name)
+		logger.Error(nil, "Server lacks a domain label", "namespace", obj.GetNamespace(), "name", obj.GetName())
 		return nil
 	}
 
@@ -184,12 +939,12 @@ func (r *DomainReconciler) serverReconciliationRequest(ctx context.Context, obj
 	// which is what it actually is.
 	domainNamespacedName := types.NamespacedName{
 		Name:      domainId, // TODO: Is this always a valid K8s resource name?
-		Namespace: server.Namespace,
+		Namespace: obj.GetNamespace(),
 	}
 	domain := &apiv1alpha1.Domain{}
 	err := r.Get(ctx, domainNamespacedName, domain)
 	if err != nil {
-		logger.Error(err, "Failed getting Domain for Server", "namespace", server.Namespace, "name", server.Name)
+		logger.Error(err, "Failed getting Domain for Server", "namespace", obj.GetNamespace(), "name", obj.GetName())
 		return nil
 	}
 
@@ -0,0 +1,348 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	envoygateway "github.com/envoyproxy/gateway/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// principalHeader is the header reconcileSecurityPolicy injects the JWT's "sub" claim into,
+// which reconcileBackendTrafficPolicy then keys RateLimitKeyPrincipal rate limiting on. Envoy
+// Gateway requires a header (there's no native "rate limit by JWT claim" selector), so a claim
+// extracted by the SecurityPolicy's own JWT filter is the only way to get it in front of the
+// rate limit filter.
+const principalHeader = "x-vimana-principal"
+
+// DomainPolicyReconciler reconciles a DomainPolicy object.
+type DomainPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// Return true iff the two objects are *not* equal.
+func clientTrafficPolicySpecDiffers(actual, expected *envoygateway.ClientTrafficPolicy) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
+}
+
+// Mutate the "spec" value of the receiver to match that of the other object.
+func clientTrafficPolicyCopySpec(receiver, giver *envoygateway.ClientTrafficPolicy) {
+	receiver.Spec = giver.Spec
+}
+
+// Return true iff the two objects are *not* equal.
+func backendTrafficPolicySpecDiffers(actual, expected *envoygateway.BackendTrafficPolicy) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
+}
+
+// Mutate the "spec" value of the receiver to match that of the other object.
+func backendTrafficPolicyCopySpec(receiver, giver *envoygateway.BackendTrafficPolicy) {
+	receiver.Spec = giver.Spec
+}
+
+// Return true iff the two objects are *not* equal.
+func securityPolicySpecDiffers(actual, expected *envoygateway.SecurityPolicy) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
+}
+
+// Mutate the "spec" value of the receiver to match that of the other object.
+func securityPolicyCopySpec(receiver, giver *envoygateway.SecurityPolicy) {
+	receiver.Spec = giver.Spec
+}
+
+// +kubebuilder:rbac:groups=api.vimana.host,resources=domainpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=api.vimana.host,resources=domainpolicies/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *DomainPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	policy := &apiv1alpha1.DomainPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("DomainPolicy not found, assumed deleted", "namespace", req.Namespace, "name", req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get DomainPolicy", "namespace", req.Namespace, "name", req.Name)
+		return ctrl.Result{}, err
+	}
+
+	domain := &apiv1alpha1.Domain{}
+	domainNamespacedName := types.NamespacedName{Name: string(policy.Spec.TargetRef.Name), Namespace: req.Namespace}
+	if err := r.Get(ctx, domainNamespacedName, domain); err != nil {
+		if apierrors.IsNotFound(err) {
+			message := fmt.Sprintf("Target Domain %q not found", policy.Spec.TargetRef.Name)
+			return ctrl.Result{}, r.updateEnforcedStatus(ctx, policy, metav1.ConditionFalse, "DomainNotFound", message)
+		}
+		logger.Error(err, "Failed to get target Domain", "namespace", req.Namespace, "name", policy.Spec.TargetRef.Name)
+		return ctrl.Result{}, err
+	}
+
+	if policy.Spec.RateLimit != nil && policy.Spec.RateLimit.Key == apiv1alpha1.RateLimitKeyPrincipal &&
+		(policy.Spec.Auth == nil || policy.Spec.Auth.Jwt == nil) {
+		// There's no authenticated principal to key the rate limit on without a JWT filter in
+		// front of it (see principalHeader). Report this loudly rather than silently falling
+		// back to a global/per-IP limit that doesn't match the user's configured Key.
+		message := "RateLimit.Key is Principal, but Auth.Jwt is not configured, so there's no principal to key the limit on"
+		return ctrl.Result{}, r.updateEnforcedStatus(ctx, policy, metav1.ConditionFalse, "RateLimitKeyUnsupported", message)
+	}
+
+	// Scope every Envoy Gateway resource to exactly the 1 listener section that serves this
+	// domain's canonical hostname, so that the policy can't leak onto some other domain's
+	// listener sharing the same Gateway.
+	sectionName := gwapiv1alpha2.SectionName(fmt.Sprintf("l-%s", domainHash(canonicalDomain(domain.Spec.Id))))
+	targetRef := gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gwapiv1alpha2.LocalPolicyTargetReference{
+			Group: gwapiv1alpha2.Group("gateway.networking.k8s.io"),
+			Kind:  gwapiv1alpha2.Kind("Gateway"),
+			Name:  gwapiv1alpha2.ObjectName(gatewayName(domain.Spec.Vimana)),
+		},
+		SectionName: &sectionName,
+	}
+
+	if err := r.reconcileClientTrafficPolicy(ctx, policy, targetRef); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileSecurityPolicy(ctx, policy, targetRef); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileBackendTrafficPolicy(ctx, policy, targetRef); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.updateEnforcedStatus(ctx, policy, metav1.ConditionTrue, "Enforced", "Envoy Gateway policies reconciled for the target Domain's listener")
+}
+
+// resourceName derives a deterministic, valid K8s name for one of the Envoy Gateway resources
+// owned by a DomainPolicy.
+func (r *DomainPolicyReconciler) resourceName(policy *apiv1alpha1.DomainPolicy, kind rune) string {
+	return prefixed(hashed(fmt.Sprintf("%s/%s", policy.Namespace, policy.Name)), kind)
+}
+
+// reconcileClientTrafficPolicy ensures a ClientTrafficPolicy requiring the mTLS client
+// certificate configured by policy.Spec.Auth.Mtls exists iff that field is set.
+func (r *DomainPolicyReconciler) reconcileClientTrafficPolicy(
+	ctx context.Context, policy *apiv1alpha1.DomainPolicy, targetRef gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName,
+) error {
+	namespacedName := types.NamespacedName{Name: r.resourceName(policy, 'c'), Namespace: policy.Namespace}
+	if policy.Spec.Auth == nil || policy.Spec.Auth.Mtls == nil {
+		return ensureResourceDeleted(r.Client, ctx, namespacedName, &envoygateway.ClientTrafficPolicy{})
+	}
+
+	expected := &envoygateway.ClientTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: namespacedName.Name, Namespace: namespacedName.Namespace},
+		Spec: envoygateway.ClientTrafficPolicySpec{
+			PolicyTargetReferences: envoygateway.PolicyTargetReferences{
+				TargetRefs: []gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName{targetRef},
+			},
+			TLS: &envoygateway.TLSSettings{
+				ClientValidation: &envoygateway.ClientValidationContext{
+					CACertificateRefs: []gwapi.SecretObjectReference{
+						{Name: gwapi.ObjectName(policy.Spec.Auth.Mtls.ClientCaSecretRef.Name)},
+					},
+				},
+			},
+		},
+	}
+	if err := ctrl.SetControllerReference(policy, expected, r.Scheme); err != nil {
+		return err
+	}
+	return ensureResourceHasSpecAndLabels(
+		r.Client, ctx, namespacedName, &envoygateway.ClientTrafficPolicy{}, expected, clientTrafficPolicySpecDiffers, clientTrafficPolicyCopySpec,
+	)
+}
+
+// reconcileSecurityPolicy ensures a SecurityPolicy requiring the JWT configured by
+// policy.Spec.Auth.Jwt exists iff that field is set.
+func (r *DomainPolicyReconciler) reconcileSecurityPolicy(
+	ctx context.Context, policy *apiv1alpha1.DomainPolicy, targetRef gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName,
+) error {
+	namespacedName := types.NamespacedName{Name: r.resourceName(policy, 'j'), Namespace: policy.Namespace}
+	if policy.Spec.Auth == nil || policy.Spec.Auth.Jwt == nil {
+		return ensureResourceDeleted(r.Client, ctx, namespacedName, &envoygateway.SecurityPolicy{})
+	}
+
+	provider := envoygateway.JWTProvider{
+		Name:   "default",
+		Issuer: policy.Spec.Auth.Jwt.Issuer,
+		RemoteJWKS: &envoygateway.RemoteJWKS{
+			URI: policy.Spec.Auth.Jwt.JwksUrl,
+		},
+	}
+	if policy.Spec.RateLimit != nil && policy.Spec.RateLimit.Key == apiv1alpha1.RateLimitKeyPrincipal {
+		// Extract the "sub" claim into principalHeader so reconcileBackendTrafficPolicy can key
+		// the rate limit on the authenticated principal rather than the client IP.
+		provider.ClaimToHeaders = []envoygateway.ClaimToHeader{
+			{Header: principalHeader, Claim: "sub"},
+		}
+	}
+
+	expected := &envoygateway.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: namespacedName.Name, Namespace: namespacedName.Namespace},
+		Spec: envoygateway.SecurityPolicySpec{
+			PolicyTargetReferences: envoygateway.PolicyTargetReferences{
+				TargetRefs: []gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName{targetRef},
+			},
+			JWT: &envoygateway.JWT{
+				Providers: []envoygateway.JWTProvider{provider},
+			},
+		},
+	}
+	if err := ctrl.SetControllerReference(policy, expected, r.Scheme); err != nil {
+		return err
+	}
+	return ensureResourceHasSpecAndLabels(
+		r.Client, ctx, namespacedName, &envoygateway.SecurityPolicy{}, expected, securityPolicySpecDiffers, securityPolicyCopySpec,
+	)
+}
+
+// reconcileBackendTrafficPolicy ensures a BackendTrafficPolicy enforcing the rate limit
+// configured by policy.Spec.RateLimit exists iff that field is set.
+func (r *DomainPolicyReconciler) reconcileBackendTrafficPolicy(
+	ctx context.Context, policy *apiv1alpha1.DomainPolicy, targetRef gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName,
+) error {
+	namespacedName := types.NamespacedName{Name: r.resourceName(policy, 'b'), Namespace: policy.Namespace}
+	if policy.Spec.RateLimit == nil {
+		return ensureResourceDeleted(r.Client, ctx, namespacedName, &envoygateway.BackendTrafficPolicy{})
+	}
+
+	expected := &envoygateway.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: namespacedName.Name, Namespace: namespacedName.Namespace},
+		Spec: envoygateway.BackendTrafficPolicySpec{
+			PolicyTargetReferences: envoygateway.PolicyTargetReferences{
+				TargetRefs: []gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName{targetRef},
+			},
+			RateLimit: &envoygateway.RateLimitSpec{
+				Type: envoygateway.GlobalRateLimitType,
+				Global: &envoygateway.GlobalRateLimit{
+					Rules: []envoygateway.RateLimitRule{
+						{
+							ClientSelectors: []envoygateway.RateLimitSelectCondition{rateLimitClientSelector(policy.Spec.RateLimit.Key)},
+							Limit: envoygateway.RateLimitValue{
+								Requests: uint(policy.Spec.RateLimit.RequestsPerSecond),
+								Unit:     envoygateway.RateLimitUnit("Second"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := ctrl.SetControllerReference(policy, expected, r.Scheme); err != nil {
+		return err
+	}
+	return ensureResourceHasSpecAndLabels(
+		r.Client, ctx, namespacedName, &envoygateway.BackendTrafficPolicy{}, expected, backendTrafficPolicySpecDiffers, backendTrafficPolicyCopySpec,
+	)
+}
+
+// rateLimitClientSelector returns the RateLimitSelectCondition that buckets requests by key: a
+// Distinct match against the source IP for RateLimitKeyClientIp (Distinct carves the 0.0.0.0/0
+// CIDR into one bucket per distinct address, rather than one shared global bucket), or a
+// Distinct match against principalHeader (populated by reconcileSecurityPolicy's ClaimToHeaders)
+// for RateLimitKeyPrincipal. The caller is responsible for rejecting RateLimitKeyPrincipal when
+// no JWT filter is configured to populate that header; see the RateLimitKeyUnsupported check in
+// Reconcile.
+func rateLimitClientSelector(key apiv1alpha1.RateLimitKey) envoygateway.RateLimitSelectCondition {
+	if key == apiv1alpha1.RateLimitKeyPrincipal {
+		return envoygateway.RateLimitSelectCondition{
+			Headers: []envoygateway.HeaderMatch{
+				{Type: ptr.To(envoygateway.HeaderMatchDistinct), Name: principalHeader},
+			},
+		}
+	}
+	return envoygateway.RateLimitSelectCondition{
+		SourceCIDR: &envoygateway.SourceMatch{
+			Type:  ptr.To(envoygateway.SourceMatchDistinct),
+			Value: "0.0.0.0/0",
+		},
+	}
+}
+
+// updateEnforcedStatus records whether this DomainPolicy's Envoy Gateway resources have been
+// reconciled, both on the policy itself and aggregated onto the owning Vimana so that users
+// don't have to inspect every DomainPolicy individually to know whether their intent is live.
+func (r *DomainPolicyReconciler) updateEnforcedStatus(
+	ctx context.Context, policy *apiv1alpha1.DomainPolicy, status metav1.ConditionStatus, reason, message string,
+) error {
+	logger := log.FromContext(ctx)
+
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type: "Enforced", Status: status, Reason: reason, Message: message,
+	})
+	if err := r.Status().Update(ctx, policy); err != nil {
+		logger.Error(err, "Failed to update DomainPolicy status", "namespace", policy.Namespace, "name", policy.Name)
+		return err
+	}
+
+	return r.updatePoliciesEnforcedCondition(ctx, policy.Namespace)
+}
+
+// updatePoliciesEnforcedCondition aggregates every DomainPolicy's Enforced condition in the
+// given namespace onto the namespace's Vimana, if one exists.
+func (r *DomainPolicyReconciler) updatePoliciesEnforcedCondition(ctx context.Context, namespace string) error {
+	logger := log.FromContext(ctx)
+
+	vimanas := &apiv1alpha1.VimanaList{}
+	if err := r.List(ctx, vimanas, client.InNamespace(namespace)); err != nil {
+		logger.Error(err, "Failed to list Vimanas", "namespace", namespace)
+		return err
+	}
+	if len(vimanas.Items) != 1 {
+		// No (or more than 1) Vimana in the namespace: nothing unambiguous to aggregate onto.
+		return nil
+	}
+	vimana := &vimanas.Items[0]
+
+	policies := &apiv1alpha1.DomainPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(namespace)); err != nil {
+		logger.Error(err, "Failed to list DomainPolicies", "namespace", namespace)
+		return err
+	}
+
+	status, reason, message := metav1.ConditionTrue, "Enforced", "Every DomainPolicy in the namespace is enforced"
+	for _, policy := range policies.Items {
+		if enforced := meta.FindStatusCondition(policy.Status.Conditions, "Enforced"); enforced == nil || enforced.Status != metav1.ConditionTrue {
+			status = metav1.ConditionFalse
+			reason, message = "NotEnforced", fmt.Sprintf("DomainPolicy %q is not yet enforced", policy.Name)
+			break
+		}
+	}
+
+	meta.SetStatusCondition(&vimana.Status.Conditions, metav1.Condition{
+		Type: "PoliciesEnforced", Status: status, Reason: reason, Message: message,
+	})
+	if err := r.Status().Update(ctx, vimana); err != nil {
+		logger.Error(err, "Failed to update Vimana PoliciesEnforced condition", "namespace", vimana.Namespace, "name", vimana.Name)
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DomainPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1alpha1.DomainPolicy{}).
+		Owns(&envoygateway.ClientTrafficPolicy{}).
+		Owns(&envoygateway.SecurityPolicy{}).
+		Owns(&envoygateway.BackendTrafficPolicy{}).
+		Complete(r)
+}
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"context"
+
+	envoygateway "github.com/envoyproxy/gateway/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+var _ = Describe("DomainPolicy Controller", func() {
+	Context("When reconciling a resource", func() {
+		const namespace = "default"
+		const resourceName = "test-policy"
+		const domainId = "fedcba0123456789fedcba0123456789"
+		const vimanaId = "policy-vimana"
+		const requestsPerSecond = int32(100)
+
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+		BeforeEach(func() {
+			domain := &apiv1alpha1.Domain{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: domainId, Namespace: namespace}, domain)
+			if err != nil && errors.IsNotFound(err) {
+				Expect(k8sClient.Create(ctx, &apiv1alpha1.Domain{
+					ObjectMeta: metav1.ObjectMeta{Name: domainId, Namespace: namespace},
+					Spec:       apiv1alpha1.DomainSpec{Id: domainId, Vimana: vimanaId},
+				})).To(Succeed())
+			}
+
+			policy := &apiv1alpha1.DomainPolicy{}
+			err = k8sClient.Get(ctx, typeNamespacedName, policy)
+			if err != nil && errors.IsNotFound(err) {
+				Expect(k8sClient.Create(ctx, &apiv1alpha1.DomainPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+					Spec: apiv1alpha1.DomainPolicySpec{
+						TargetRef: gwapiv1alpha2.LocalPolicyTargetReference{
+							Group: "api.vimana.host",
+							Kind:  "Domain",
+							Name:  domainId,
+						},
+						RateLimit: &apiv1alpha1.RateLimitPolicy{RequestsPerSecond: requestsPerSecond},
+					},
+				})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			policy := &apiv1alpha1.DomainPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+
+			domain := &apiv1alpha1.Domain{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: domainId, Namespace: namespace}, domain)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, domain)).To(Succeed())
+		})
+
+		It("should create a BackendTrafficPolicy enforcing the configured rate limit", func() {
+			controllerReconciler := &DomainPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &apiv1alpha1.DomainPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			enforced := meta.FindStatusCondition(policy.Status.Conditions, "Enforced")
+			Expect(enforced).NotTo(BeNil())
+			Expect(enforced.Status).To(Equal(metav1.ConditionTrue))
+
+			btp := &envoygateway.BackendTrafficPolicy{}
+			err = k8sClient.Get(ctx, types.NamespacedName{
+				Name:      controllerReconciler.resourceName(policy, 'b'),
+				Namespace: namespace,
+			}, btp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(btp.Spec.RateLimit.Global.Rules).To(HaveLen(1))
+			Expect(btp.Spec.RateLimit.Global.Rules[0].Limit.Requests).To(Equal(uint(requestsPerSecond)))
+
+			By("verifying the default ClientIp key is enforced per distinct source address, not as 1 shared global bucket")
+			selectors := btp.Spec.RateLimit.Global.Rules[0].ClientSelectors
+			Expect(selectors).To(HaveLen(1))
+			Expect(selectors[0].SourceCIDR).NotTo(BeNil())
+			Expect(selectors[0].SourceCIDR.Value).To(Equal("0.0.0.0/0"))
+			Expect(selectors[0].SourceCIDR.Type).NotTo(BeNil())
+			Expect(*selectors[0].SourceCIDR.Type).To(Equal(envoygateway.SourceMatchDistinct))
+
+			ctp := &envoygateway.ClientTrafficPolicy{}
+			err = k8sClient.Get(ctx, types.NamespacedName{
+				Name:      controllerReconciler.resourceName(policy, 'c'),
+				Namespace: namespace,
+			}, ctp)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "Expected no ClientTrafficPolicy since Auth is unset")
+		})
+
+		It("should report DomainNotFound when the target Domain does not exist", func() {
+			controllerReconciler := &DomainPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			policy := &apiv1alpha1.DomainPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			policy.Spec.TargetRef.Name = "does-not-exist"
+			Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			enforced := meta.FindStatusCondition(policy.Status.Conditions, "Enforced")
+			Expect(enforced).NotTo(BeNil())
+			Expect(enforced.Status).To(Equal(metav1.ConditionFalse))
+			Expect(enforced.Reason).To(Equal("DomainNotFound"))
+		})
+
+		It("should report RateLimitKeyUnsupported when Key is Principal but no Jwt auth is configured", func() {
+			controllerReconciler := &DomainPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			policy := &apiv1alpha1.DomainPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			policy.Spec.RateLimit.Key = apiv1alpha1.RateLimitKeyPrincipal
+			Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			enforced := meta.FindStatusCondition(policy.Status.Conditions, "Enforced")
+			Expect(enforced).NotTo(BeNil())
+			Expect(enforced.Status).To(Equal(metav1.ConditionFalse))
+			Expect(enforced.Reason).To(Equal("RateLimitKeyUnsupported"))
+		})
+
+		It("should key the rate limit on the JWT-injected principal header when Key is Principal and Jwt auth is configured", func() {
+			controllerReconciler := &DomainPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			policy := &apiv1alpha1.DomainPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			policy.Spec.RateLimit.Key = apiv1alpha1.RateLimitKeyPrincipal
+			policy.Spec.Auth = &apiv1alpha1.AuthPolicy{
+				Jwt: &apiv1alpha1.JwtAuth{Issuer: "https://issuer.example.com", JwksUrl: "https://issuer.example.com/jwks.json"},
+			}
+			Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			enforced := meta.FindStatusCondition(policy.Status.Conditions, "Enforced")
+			Expect(enforced).NotTo(BeNil())
+			Expect(enforced.Status).To(Equal(metav1.ConditionTrue))
+
+			sp := &envoygateway.SecurityPolicy{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      controllerReconciler.resourceName(policy, 'j'),
+				Namespace: namespace,
+			}, sp)).To(Succeed())
+			Expect(sp.Spec.JWT.Providers).To(HaveLen(1))
+			Expect(sp.Spec.JWT.Providers[0].ClaimToHeaders).To(Equal([]envoygateway.ClaimToHeader{
+				{Header: principalHeader, Claim: "sub"},
+			}))
+
+			btp := &envoygateway.BackendTrafficPolicy{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      controllerReconciler.resourceName(policy, 'b'),
+				Namespace: namespace,
+			}, btp)).To(Succeed())
+			selectors := btp.Spec.RateLimit.Global.Rules[0].ClientSelectors
+			Expect(selectors).To(HaveLen(1))
+			Expect(selectors[0].Headers).To(HaveLen(1))
+			Expect(selectors[0].Headers[0].Name).To(Equal(principalHeader))
+			Expect(selectors[0].Headers[0].Type).NotTo(BeNil())
+			Expect(*selectors[0].Headers[0].Type).To(Equal(envoygateway.HeaderMatchDistinct))
+		})
+	})
+})
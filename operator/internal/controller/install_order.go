@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"sort"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"vimana.host/operator/pkg/readiness"
+)
+
+// installRank assigns a relative install-order rank to every kind this operator manages,
+// mirroring Helm 3's InstallOrder (as reused by ONAP rsync): lower-ranked kinds are applied, and
+// made Ready, before anything ranked higher that might depend on them. Kinds with no entry here
+// default to rank 0, the same as Namespace, so they are applied first.
+var installRank = map[string]int{
+	"Namespace":                0,
+	"ServiceAccount":           1,
+	"Secret":                   1,
+	"ConfigMap":                1,
+	"ClusterRole":              2,
+	"ClusterRoleBinding":       2,
+	"Role":                     2,
+	"RoleBinding":              2,
+	"CustomResourceDefinition": 3,
+	"PersistentVolumeClaim":    4,
+	"Certificate":              4,
+	"Service":                  5,
+	"Deployment":               6,
+	"StatefulSet":              6,
+	"Gateway":                  7,
+	"HTTPRoute":                7,
+	"GRPCRoute":                7,
+}
+
+// rankOf returns the installRank for kind, defaulting to 0 (applied alongside Namespaces, first)
+// for any kind this table doesn't name.
+func rankOf(kind string) int {
+	return installRank[kind]
+}
+
+// orderedStep is one kind-ranked unit of work in an applyOrdered call: Apply reconciles the
+// resource (typically a single ensureResourceHasSpecAndLabels call), and Ready reports whether
+// it has actually come up yet (see pkg/readiness), not merely whether Apply succeeded.
+type orderedStep struct {
+	Kind  string
+	Apply func(ctx context.Context) error
+	Ready func(ctx context.Context) (readiness.Entry, error)
+}
+
+// applyOrdered runs steps in ascending installRank order, applying and then checking the
+// readiness of each one before proceeding to the next rank — so, e.g., a Deployment is never
+// reconciled ahead of the Service its Pods' DNS depends on. It stops at the first step whose
+// resource isn't yet Ready and asks the caller to requeue after readinessRequeueDelay, rather than racing ahead
+// to higher-ranked steps against a dependency that only just got created.
+func applyOrdered(ctx context.Context, steps []orderedStep) (ctrl.Result, error) {
+	ordered := append([]orderedStep(nil), steps...)
+	sort.SliceStable(ordered, func(i, j int) bool { return rankOf(ordered[i].Kind) < rankOf(ordered[j].Kind) })
+
+	for _, step := range ordered {
+		if err := step.Apply(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		entry, err := step.Ready(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !entry.Ready {
+			return ctrl.Result{RequeueAfter: readinessRequeueDelay}, nil
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// deleteStep is one kind-ranked resource to remove in a deleteOrdered call.
+type deleteStep struct {
+	Kind   string
+	Delete func(ctx context.Context) error
+}
+
+// deleteOrdered runs steps in descending installRank order — the reverse of applyOrdered — so a
+// resource is always torn down before anything it depends on: a Gateway before the backing
+// Services it routes to, those before the ConfigMaps/Secrets they mount, and so on.
+func deleteOrdered(ctx context.Context, steps []deleteStep) error {
+	ordered := append([]deleteStep(nil), steps...)
+	sort.SliceStable(ordered, func(i, j int) bool { return rankOf(ordered[i].Kind) > rankOf(ordered[j].Kind) })
+
+	for _, step := range ordered {
+		if err := step.Delete(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
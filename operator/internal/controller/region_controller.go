@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+	"vimana.host/operator/pkg/features"
+)
+
+// globalRegionsEnabled reports whether Region resolution also considers Regions living in
+// globalRegionsNamespace, turning the otherwise namespace-scoped Region CRD into a
+// cluster-wide pool shared by every namespace. Governed by the NamespaceScopedRegions gate,
+// which is on by default, matching every other CRD in this operator, which are strictly
+// namespace-scoped.
+func globalRegionsEnabled() bool {
+	return !features.Enabled(features.NamespaceScopedRegions)
+}
+
+// globalRegionsNamespace is where shared Region objects live when globalRegionsEnabled.
+const globalRegionsNamespace = "vimana-regions"
+
+// RegionReconciler reconciles a Region object.
+type RegionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=api.vimana.host,resources=regions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=api.vimana.host,resources=regions/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *RegionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	region := &apiv1alpha1.Region{}
+	if err := r.Get(ctx, req.NamespacedName, region); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Region not found, assumed deleted", "namespace", req.Namespace, "name", req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get Region", "namespace", req.Namespace, "name", req.Name)
+		return ctrl.Result{}, err
+	}
+
+	status, reason, message := metav1.ConditionTrue, "Healthy", "Region reports itself healthy"
+	if !region.Spec.Healthy {
+		status, reason, message = metav1.ConditionFalse, "Unhealthy", "Region reports itself unhealthy"
+	}
+	return ctrl.Result{}, updateAvailabilityStatus(r.Client, ctx, region, status, reason, message)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RegionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1alpha1.Region{}).
+		Complete(r)
+}
+
+// resolveRegions looks up, for every name in names, whether a live (non-deleting) Region with
+// that FailureDomain exists in namespace (or, if globalRegionsEnabled, in
+// globalRegionsNamespace). Returns the subset of names that resolved, and the subset that
+// didn't match any Region.
+func resolveRegions(ctx context.Context, c client.Client, namespace string, names []string) (resolved, unresolved []string, err error) {
+	logger := log.FromContext(ctx)
+
+	regions := &apiv1alpha1.RegionList{}
+	if err := c.List(ctx, regions, client.InNamespace(namespace)); err != nil {
+		logger.Error(err, "Failed to list Regions", "namespace", namespace)
+		return nil, nil, err
+	}
+	items := regions.Items
+
+	if globalRegionsEnabled() {
+		global := &apiv1alpha1.RegionList{}
+		if err := c.List(ctx, global, client.InNamespace(globalRegionsNamespace)); err != nil {
+			logger.Error(err, "Failed to list global Regions", "namespace", globalRegionsNamespace)
+			return nil, nil, err
+		}
+		items = append(items, global.Items...)
+	}
+
+	live := map[string]bool{}
+	for _, region := range items {
+		if region.DeletionTimestamp.IsZero() {
+			live[region.Spec.FailureDomain] = true
+		}
+	}
+
+	for _, name := range names {
+		if live[name] {
+			resolved = append(resolved, name)
+		} else {
+			unresolved = append(unresolved, name)
+		}
+	}
+	return resolved, unresolved, nil
+}
+
+// regionHealthy reports whether at least 1 live Region matching any of names is Healthy. If
+// names is empty (no region restriction configured), it's vacuously considered healthy.
+func regionHealthy(ctx context.Context, c client.Client, namespace string, names []string) (bool, error) {
+	if len(names) == 0 {
+		return true, nil
+	}
+	logger := log.FromContext(ctx)
+
+	namespaces := []string{namespace}
+	if globalRegionsEnabled() {
+		namespaces = append(namespaces, globalRegionsNamespace)
+	}
+
+	requested := map[string]bool{}
+	for _, name := range names {
+		requested[name] = true
+	}
+
+	for _, ns := range namespaces {
+		regions := &apiv1alpha1.RegionList{}
+		if err := c.List(ctx, regions, client.InNamespace(ns)); err != nil {
+			logger.Error(err, "Failed to list Regions", "namespace", ns)
+			return false, err
+		}
+		for _, region := range regions.Items {
+			if region.DeletionTimestamp.IsZero() && requested[region.Spec.FailureDomain] && region.Spec.Healthy {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// domainsReferencingRegion maps a changed Region to every Domain in its namespace whose
+// Spec.Regions names it, so that Regions coming and going re-evaluates the Domains that care.
+func domainsReferencingRegion(ctx context.Context, c client.Client, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	region := obj.(*apiv1alpha1.Region)
+
+	domains := &apiv1alpha1.DomainList{}
+	if globalRegionsEnabled() && region.Namespace == globalRegionsNamespace {
+		// A global Region can be referenced from any namespace; re-evaluate every Domain.
+		if err := c.List(ctx, domains); err != nil {
+			logger.Error(err, "Failed to list Domains")
+			return nil
+		}
+	} else if err := c.List(ctx, domains, client.InNamespace(region.Namespace)); err != nil {
+		logger.Error(err, "Failed to list Domains", "namespace", region.Namespace)
+		return nil
+	}
+	return domainRequestsReferencing(domains.Items, region.Spec.FailureDomain)
+}
+
+func domainRequestsReferencing(domains []apiv1alpha1.Domain, failureDomain string) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, domain := range domains {
+		if containsString(domain.Spec.Regions, failureDomain) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: domain.Name, Namespace: domain.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// componentsReferencingRegion maps a changed Region to every Component whose owning Domain
+// (in the Region's namespace) references it.
+func componentsReferencingRegion(ctx context.Context, c client.Client, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	region := obj.(*apiv1alpha1.Region)
+
+	domains := &apiv1alpha1.DomainList{}
+	if err := c.List(ctx, domains, client.InNamespace(region.Namespace)); err != nil {
+		logger.Error(err, "Failed to list Domains", "namespace", region.Namespace)
+		return nil
+	}
+
+	referencing := map[string]bool{}
+	for _, domain := range domains.Items {
+		if containsString(domain.Spec.Regions, region.Spec.FailureDomain) {
+			referencing[domain.Spec.Id] = true
+		}
+	}
+	if len(referencing) == 0 {
+		return nil
+	}
+
+	components := &apiv1alpha1.ComponentList{}
+	if err := c.List(ctx, components, client.InNamespace(region.Namespace)); err != nil {
+		logger.Error(err, "Failed to list Components", "namespace", region.Namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, component := range components.Items {
+		if referencing[component.Spec.Domain] {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: component.Name, Namespace: component.Namespace},
+			})
+		}
+	}
+	return requests
+}
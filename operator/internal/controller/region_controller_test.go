@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+var _ = Describe("Region Controller", func() {
+	Context("When reconciling a resource", func() {
+		const namespace = "default"
+		const resourceName = "test-region"
+		const failureDomain = "aws/us-east-1"
+
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+		BeforeEach(func() {
+			region := &apiv1alpha1.Region{}
+			err := k8sClient.Get(ctx, typeNamespacedName, region)
+			if err != nil && errors.IsNotFound(err) {
+				Expect(k8sClient.Create(ctx, &apiv1alpha1.Region{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+					Spec:       apiv1alpha1.RegionSpec{FailureDomain: failureDomain},
+				})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			region := &apiv1alpha1.Region{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, region)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, region)).To(Succeed())
+		})
+
+		It("should report itself Unavailable when Spec.Healthy is false", func() {
+			controllerReconciler := &RegionReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			region := &apiv1alpha1.Region{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, region)).To(Succeed())
+			available := meta.FindStatusCondition(region.Status.Conditions, "Available")
+			Expect(available).NotTo(BeNil())
+			Expect(available.Status).To(Equal(metav1.ConditionFalse))
+			Expect(available.Reason).To(Equal("Unhealthy"))
+		})
+
+		It("should report itself Available once Spec.Healthy flips to true", func() {
+			region := &apiv1alpha1.Region{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, region)).To(Succeed())
+			region.Spec.Healthy = true
+			Expect(k8sClient.Update(ctx, region)).To(Succeed())
+
+			controllerReconciler := &RegionReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, region)).To(Succeed())
+			available := meta.FindStatusCondition(region.Status.Conditions, "Available")
+			Expect(available).NotTo(BeNil())
+			Expect(available.Status).To(Equal(metav1.ConditionTrue))
+			Expect(available.Reason).To(Equal("Healthy"))
+		})
+	})
+})
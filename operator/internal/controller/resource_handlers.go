@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+
+	"vimana.host/operator/pkg/readiness"
+)
+
+// ResourceHandler teaches the operator everything it needs to manage one kind of child resource,
+// replacing the specDiffers/copySpec closure pair every reconciler used to hand-thread through
+// ensureResourceHasSpecAndLabels (inspired by ONAP k8splugin's per-kind plugin design). Add a new
+// kind by implementing ResourceHandler, calling registerResourceHandler once in this file's
+// init(), and adding its Go type to gvkOf, instead of teaching every call site about it.
+type ResourceHandler interface {
+	// GVK identifies the kind this handler manages.
+	GVK() schema.GroupVersionKind
+
+	// Diff reports whether actual has drifted from expected in a way that needs an Update.
+	Diff(actual, expected client.Object) bool
+
+	// Merge copies expected's managed fields onto actual, in place, so actual is ready to Update.
+	Merge(actual, expected client.Object)
+
+	// IsReady reports whether actual has actually come up, and why (or why not); see pkg/readiness.
+	IsReady(actual client.Object) (bool, string)
+}
+
+// PreCreateHook lets a ResourceHandler mutate expected immediately before it's created, e.g. to
+// fill in a field that can only be computed once we know no live object exists yet.
+type PreCreateHook interface {
+	PreCreate(ctx context.Context, expected client.Object) error
+}
+
+// PostDeleteHook lets a ResourceHandler clean up state that outlives the resource it was attached
+// to, once it's actually gone.
+type PostDeleteHook interface {
+	PostDelete(ctx context.Context, namespacedName types.NamespacedName) error
+}
+
+// resourceHandlers is the GVK -> ResourceHandler registry populated by registerResourceHandler.
+var resourceHandlers = map[schema.GroupVersionKind]ResourceHandler{}
+
+// registerResourceHandler teaches the operator about one kind of managed resource.
+func registerResourceHandler(h ResourceHandler) {
+	resourceHandlers[h.GVK()] = h
+}
+
+func init() {
+	registerResourceHandler(deploymentHandler{})
+	registerResourceHandler(serviceHandler{})
+	registerResourceHandler(configMapHandler{})
+	registerResourceHandler(secretHandler{})
+	registerResourceHandler(gatewayHandler{})
+	registerResourceHandler(httpRouteHandler{})
+}
+
+// gvkOf resolves obj's GroupVersionKind from its concrete Go type, the same dispatch
+// pkg/readiness's checkerFor uses for Checkers. Add a case here alongside a new ResourceHandler.
+func gvkOf(obj client.Object) schema.GroupVersionKind {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return appsv1.SchemeGroupVersion.WithKind("Deployment")
+	case *corev1.Service:
+		return corev1.SchemeGroupVersion.WithKind("Service")
+	case *corev1.ConfigMap:
+		return corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	case *corev1.Secret:
+		return corev1.SchemeGroupVersion.WithKind("Secret")
+	case *gwapi.Gateway:
+		return gwapi.GroupVersion.WithKind("Gateway")
+	case *gwapi.HTTPRoute:
+		return gwapi.GroupVersion.WithKind("HTTPRoute")
+	default:
+		return schema.GroupVersionKind{}
+	}
+}
+
+// handlerFor resolves the registered ResourceHandler for obj's kind, if any.
+func handlerFor(obj client.Object) (ResourceHandler, bool) {
+	h, ok := resourceHandlers[gvkOf(obj)]
+	return h, ok
+}
+
+// ensureManagedResource is ensureResourceHasSpecAndLabels for a kind with a registered
+// ResourceHandler: Diff/Merge, and the optional PreCreate hook, come from the registry instead of
+// being threaded through by the caller as closures.
+func ensureManagedResource(
+	c client.Client, ctx context.Context, namespacedName types.NamespacedName, actual, expected client.Object,
+) error {
+	handler, ok := handlerFor(expected)
+	if !ok {
+		return fmt.Errorf("no ResourceHandler registered for %T", expected)
+	}
+
+	logger := log.FromContext(ctx)
+	err := c.Get(ctx, namespacedName, actual)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to get resource", "namespace", namespacedName.Namespace, "name", namespacedName.Name)
+			return err
+		}
+		if hook, ok := handler.(PreCreateHook); ok {
+			if err := hook.PreCreate(ctx, expected); err != nil {
+				logger.Error(err, "PreCreate hook failed", "namespace", namespacedName.Namespace, "name", namespacedName.Name)
+				return err
+			}
+		}
+		if err := c.Create(ctx, expected); err != nil {
+			logger.Error(err, "Failed to create resource", "namespace", namespacedName.Namespace, "name", namespacedName.Name)
+			return err
+		}
+		return nil
+	}
+
+	needsUpdate := false
+	if handler.Diff(actual, expected) {
+		handler.Merge(actual, expected)
+		needsUpdate = true
+	}
+	expectedLabels := expected.GetLabels()
+	if !reflect.DeepEqual(actual.GetLabels(), expectedLabels) {
+		actual.SetLabels(expectedLabels)
+		needsUpdate = true
+	}
+	if needsUpdate {
+		if err := c.Update(ctx, actual); err != nil {
+			logger.Error(err, "Failed to update resource", "namespace", namespacedName.Namespace, "name", namespacedName.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureManagedResourceDeleted is ensureResourceDeleted for a kind with a registered
+// ResourceHandler, running its optional PostDelete hook once the delete succeeds.
+func ensureManagedResourceDeleted(c client.Client, ctx context.Context, namespacedName types.NamespacedName, resource client.Object) error {
+	handler, ok := handlerFor(resource)
+	if !ok {
+		return fmt.Errorf("no ResourceHandler registered for %T", resource)
+	}
+	if err := ensureResourceDeleted(c, ctx, namespacedName, resource); err != nil {
+		return err
+	}
+	if hook, ok := handler.(PostDeleteHook); ok {
+		return hook.PostDelete(ctx, namespacedName)
+	}
+	return nil
+}
+
+// deploymentHandler manages Deployments, deferring to readiness.DeploymentChecker for IsReady.
+type deploymentHandler struct{}
+
+func (deploymentHandler) GVK() schema.GroupVersionKind {
+	return appsv1.SchemeGroupVersion.WithKind("Deployment")
+}
+
+func (deploymentHandler) Diff(actual, expected client.Object) bool {
+	a, e := actual.(*appsv1.Deployment), expected.(*appsv1.Deployment)
+	// The number of replicas is controlled externally, probably by the HPA controller.
+	// Make sure not to modify it in this controller.
+	e.Spec.Replicas = a.Spec.Replicas
+	return !reflect.DeepEqual(a.Spec, e.Spec)
+}
+
+func (deploymentHandler) Merge(actual, expected client.Object) {
+	actual.(*appsv1.Deployment).Spec = expected.(*appsv1.Deployment).Spec
+}
+
+func (deploymentHandler) IsReady(obj client.Object) (bool, string) {
+	r := readiness.Check(obj)
+	return r.Ready, r.Message
+}
+
+// serviceHandler manages Services.
+type serviceHandler struct{}
+
+func (serviceHandler) GVK() schema.GroupVersionKind {
+	return corev1.SchemeGroupVersion.WithKind("Service")
+}
+
+func (serviceHandler) Diff(actual, expected client.Object) bool {
+	return !reflect.DeepEqual(actual.(*corev1.Service).Spec, expected.(*corev1.Service).Spec)
+}
+
+func (serviceHandler) Merge(actual, expected client.Object) {
+	actual.(*corev1.Service).Spec = expected.(*corev1.Service).Spec
+}
+
+func (serviceHandler) IsReady(obj client.Object) (bool, string) {
+	r := readiness.Check(obj)
+	return r.Ready, r.Message
+}
+
+// configMapHandler manages ConfigMaps by Data, since the ConfigMaps this operator creates (e.g.
+// the per-Domain feature-flags ConfigMap) are data bags rather than spec-bearing resources.
+type configMapHandler struct{}
+
+func (configMapHandler) GVK() schema.GroupVersionKind {
+	return corev1.SchemeGroupVersion.WithKind("ConfigMap")
+}
+
+func (configMapHandler) Diff(actual, expected client.Object) bool {
+	return !reflect.DeepEqual(actual.(*corev1.ConfigMap).Data, expected.(*corev1.ConfigMap).Data)
+}
+
+func (configMapHandler) Merge(actual, expected client.Object) {
+	actual.(*corev1.ConfigMap).Data = expected.(*corev1.ConfigMap).Data
+}
+
+func (configMapHandler) IsReady(obj client.Object) (bool, string) {
+	r := readiness.Check(obj)
+	return r.Ready, r.Message
+}
+
+// secretHandler manages Secrets by Type and Data, e.g. the dockerconfigjson pull Secret that
+// VimanaReconciler.reconcileRegistryAuth creates through ensureManagedResource.
+type secretHandler struct{}
+
+func (secretHandler) GVK() schema.GroupVersionKind {
+	return corev1.SchemeGroupVersion.WithKind("Secret")
+}
+
+func (secretHandler) Diff(actual, expected client.Object) bool {
+	a, e := actual.(*corev1.Secret), expected.(*corev1.Secret)
+	return a.Type != e.Type || !reflect.DeepEqual(a.Data, e.Data)
+}
+
+func (secretHandler) Merge(actual, expected client.Object) {
+	a, e := actual.(*corev1.Secret), expected.(*corev1.Secret)
+	a.Type = e.Type
+	a.Data = e.Data
+}
+
+func (secretHandler) IsReady(obj client.Object) (bool, string) {
+	r := readiness.Check(obj)
+	return r.Ready, r.Message
+}
+
+// gatewayHandler manages Gateway API Gateways.
+type gatewayHandler struct{}
+
+func (gatewayHandler) GVK() schema.GroupVersionKind {
+	return gwapi.GroupVersion.WithKind("Gateway")
+}
+
+func (gatewayHandler) Diff(actual, expected client.Object) bool {
+	return !reflect.DeepEqual(actual.(*gwapi.Gateway).Spec, expected.(*gwapi.Gateway).Spec)
+}
+
+func (gatewayHandler) Merge(actual, expected client.Object) {
+	actual.(*gwapi.Gateway).Spec = expected.(*gwapi.Gateway).Spec
+}
+
+func (gatewayHandler) IsReady(obj client.Object) (bool, string) {
+	r := readiness.Check(obj)
+	return r.Ready, r.Message
+}
+
+// httpRouteHandler manages Gateway API HTTPRoutes.
+type httpRouteHandler struct{}
+
+func (httpRouteHandler) GVK() schema.GroupVersionKind {
+	return gwapi.GroupVersion.WithKind("HTTPRoute")
+}
+
+func (httpRouteHandler) Diff(actual, expected client.Object) bool {
+	return !reflect.DeepEqual(actual.(*gwapi.HTTPRoute).Spec, expected.(*gwapi.HTTPRoute).Spec)
+}
+
+func (httpRouteHandler) Merge(actual, expected client.Object) {
+	actual.(*gwapi.HTTPRoute).Spec = expected.(*gwapi.HTTPRoute).Spec
+}
+
+func (httpRouteHandler) IsReady(obj client.Object) (bool, string) {
+	r := readiness.Check(obj)
+	return r.Ready, r.Message
+}
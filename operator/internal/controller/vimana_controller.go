@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -21,10 +23,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	envoygateway "github.com/envoyproxy/gateway/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+	gwapibeta "sigs.k8s.io/gateway-api/apis/v1beta1"
 	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+	"vimana.host/operator/pkg/readiness"
 )
 
 const (
@@ -33,6 +39,23 @@ const (
 	gatewayClassName   = "envoy-gateway"
 	gatewayConfigName  = "envoy-gateway-config"
 	gatewayNamespace   = "envoy-gateway-system"
+
+	// Label applied to every ReferenceGrant this controller manages, identifying the owning
+	// Vimana so that stale grants can be found and removed across namespaces.
+	// ReferenceGrants can't carry an owner reference, since owner references must be
+	// same-namespace, and the whole point of a ReferenceGrant is to live in a different one.
+	labelReferenceGrantOwnerKey = "vimana.host/gateway-owner"
+
+	// vimanaFinalizer blocks deletion of a Vimana until its Gateway and the per-namespace
+	// EnvoyProxy patch have been cleaned up. The Gateway is owned and would be garbage
+	// collected anyway, but the EnvoyProxy patch lives in the shared gatewayNamespace and
+	// can't carry an owner reference there, so it needs the same explicit cleanup.
+	vimanaFinalizer = "vimana.host/gateway-cleanup"
+
+	// defaultServiceAccountName is Kubernetes' implicitly-created ServiceAccount, used by any
+	// Pod that doesn't name one explicitly, including any a Vimana user creates outside this
+	// operator's own Deployments.
+	defaultServiceAccountName = "default"
 )
 
 var (
@@ -84,12 +107,12 @@ func envoyProxyCopySpec(receiver, giver *envoygateway.EnvoyProxy) {
 }
 
 // Return true iff the two objects are *not* equal.
-func gatewaySpecDiffers(left, right *gwapi.Gateway) bool {
-	return !reflect.DeepEqual(left.Spec, right.Spec)
+func referenceGrantSpecDiffers(actual, expected *gwapibeta.ReferenceGrant) bool {
+	return !reflect.DeepEqual(actual.Spec, expected.Spec)
 }
 
 // Mutate the "spec" value of the receiver to match that of the other object.
-func gatewayCopySpec(receiver, giver *gwapi.Gateway) {
+func referenceGrantCopySpec(receiver, giver *gwapibeta.ReferenceGrant) {
 	receiver.Spec = giver.Spec
 }
 
@@ -137,7 +160,9 @@ func envoyProxyResource(name string) *envoygateway.EnvoyProxy {
 func (r *VimanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// TODO: Somehow, it should be impossible for multiple Vimanas to co-exist in a namespace.
+	// Multiple Vimanas can no longer co-exist in a namespace: the validating webhook rejects
+	// the creation of a second one, using the vimana.host/singleton label stamped by the
+	// defaulting webhook as a quick pre-check.
 
 	vimana := &apiv1alpha1.Vimana{}
 	err := r.Get(ctx, req.NamespacedName, vimana)
@@ -151,6 +176,17 @@ func (r *VimanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	if !vimana.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalizeVimana(ctx, vimana)
+	}
+	if !containsString(vimana.Finalizers, vimanaFinalizer) {
+		vimana.Finalizers = append(vimana.Finalizers, vimanaFinalizer)
+		if err = r.Update(ctx, vimana); err != nil {
+			logger.Error(err, "Failed to add finalizer to Vimana", "namespace", vimana.Namespace, "name", vimana.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Set the status as Unknown when no status is available.
 	if len(vimana.Status.Conditions) == 0 {
 		err = updateAvailabilityStatus(r.Client, ctx, vimana, metav1.ConditionUnknown, "Reconciling", "Starting reconciliation")
@@ -159,23 +195,6 @@ func (r *VimanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	}
 
-	// Start by making sure that the Vimana runtime class exists.
-	// This is a constant cluster-scoped resource that can be shared across namespaces.
-	// Because of this potential for sharing, the Vimana resource is not added as an owner,
-	// and the runtime class can outlive the original Vimana resource that caused it to be created.
-	// It would have to be cleaned up manually if you ever wanted to get rid of it after creation.
-	err = ensureClusterResourceExists(r.Client, ctx, runtimeClassName, &nodev1.RuntimeClass{}, expectedRuntimeClass)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// We also have a gateway class that is cluster-scoped
-	// and can similarly outlive it's creating Vimana.
-	err = ensureClusterResourceExists(r.Client, ctx, gatewayClassName, &gwapi.GatewayClass{}, expectedGatewayClass)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
 	gatewayName := vimana.Name + ".gateway"
 	gatewayNamespacedName := types.NamespacedName{Name: gatewayName, Namespace: vimana.Namespace}
 
@@ -185,9 +204,54 @@ func (r *VimanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	// and has a name derived from the owner's name.
 	expectedEnvoyProxy := envoyProxyResource(gatewayName)
 	envoyProxyName := types.NamespacedName{Name: expectedEnvoyProxy.Name, Namespace: expectedEnvoyProxy.Namespace}
-	err = ensureResourceHasSpec(r.Client, ctx, envoyProxyName, &envoygateway.EnvoyProxy{}, expectedEnvoyProxy, envoyProxySpecDiffers, envoyProxyCopySpec)
-	if err != nil {
-		return ctrl.Result{}, err
+
+	// Bring up the Vimana's shared, cluster-scoped config (RuntimeClass, GatewayClass) and its
+	// namespace-scoped prerequisites (the RegistryAuth Secret, the EnvoyProxy config the
+	// GatewayClass' ParametersRef points at) in installRank order before anything that depends
+	// on them. None of these has a dedicated readiness.Checker, so each becomes Ready as soon as
+	// it exists (see readiness.PresenceChecker) — the ordering still matters, since it guarantees
+	// the GatewayClass' ParametersRef target and the ServiceAccount's imagePullSecrets are in
+	// place before the Gateway (and any Pods it fronts) are reconciled below.
+	result, err := applyOrdered(ctx, []orderedStep{
+		{
+			Kind: "RuntimeClass",
+			Apply: func(ctx context.Context) error {
+				return ensureClusterResourceExists(r.Client, ctx, runtimeClassName, &nodev1.RuntimeClass{}, expectedRuntimeClass)
+			},
+			Ready: func(ctx context.Context) (readiness.Entry, error) {
+				return r.clusterResourceReadiness(ctx, runtimeClassName, &nodev1.RuntimeClass{})
+			},
+		},
+		{
+			Kind: "GatewayClass",
+			Apply: func(ctx context.Context) error {
+				return ensureClusterResourceExists(r.Client, ctx, gatewayClassName, &gwapi.GatewayClass{}, expectedGatewayClass)
+			},
+			Ready: func(ctx context.Context) (readiness.Entry, error) {
+				return r.clusterResourceReadiness(ctx, gatewayClassName, &gwapi.GatewayClass{})
+			},
+		},
+		{
+			Kind:  "Secret",
+			Apply: func(ctx context.Context) error { return r.reconcileRegistryAuth(ctx, vimana) },
+			Ready: func(ctx context.Context) (readiness.Entry, error) { return r.registryAuthSecretReadiness(ctx, vimana) },
+		},
+		{
+			Kind: "EnvoyProxy",
+			Apply: func(ctx context.Context) error {
+				return ensureResourceHasSpecAndLabels(r.Client, ctx, envoyProxyName, &envoygateway.EnvoyProxy{}, expectedEnvoyProxy, envoyProxySpecDiffers, envoyProxyCopySpec)
+			},
+			Ready: func(ctx context.Context) (readiness.Entry, error) {
+				envoyProxy := &envoygateway.EnvoyProxy{}
+				if err := r.Get(ctx, envoyProxyName, envoyProxy); err != nil {
+					return readiness.Entry{}, err
+				}
+				return readiness.Check(envoyProxy), nil
+			},
+		},
+	})
+	if err != nil || result.RequeueAfter > 0 {
+		return result, err
 	}
 
 	// List all the domains in the namespace.
@@ -198,12 +262,30 @@ func (r *VimanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	// Certificate Secrets default to living alongside their Domain (and thus the Gateway),
+	// but can be centralized in a shared namespace instead.
+	certNamespace := req.Namespace
+	if vimana.Spec.CertificateNamespace != "" {
+		certNamespace = vimana.Spec.CertificateNamespace
+	}
+	certNamespacePtr := (*gwapi.Namespace)(ptr.To(certNamespace))
+
 	if len(domains.Items) == 0 {
 		// A gateway requires at least 1 listener to be valid,
 		// If there are no domains, there are no listeners, and there can be no gateway.
-		// Make sure it does not exist.
-		err = ensureResourceDeleted(r.Client, ctx, gatewayNamespacedName, &gwapi.Gateway{})
-		return ctrl.Result{}, err
+		// Make sure it does not exist, and that no ReferenceGrants or Certificates are left
+		// behind for it.
+		if err = r.reconcileCertificateReferenceGrants(ctx, vimana, certNamespace, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err = r.reconcileCertificates(ctx, vimana, certNamespace, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err = ensureManagedResourceDeleted(r.Client, ctx, gatewayNamespacedName, &gwapi.Gateway{}); err != nil {
+			return ctrl.Result{}, err
+		}
+		// No Gateway is expected to exist, so there's nothing to aggregate readiness from.
+		return ctrl.Result{}, updateAvailabilityStatus(r.Client, ctx, vimana, metav1.ConditionTrue, "NoDomains", "No domains are configured")
 	}
 
 	// Construct the Gateway spec.
@@ -216,15 +298,36 @@ func (r *VimanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	secretKind := (*gwapi.Kind)(ptr.To("Secret"))
 
 	var listeners []gwapi.Listener
+	secretNames := map[string]struct{}{}
+	var certRequests []certificateRequest
 	for _, domain := range domains.Items {
+		issuer := vimana.Spec.CertificateIssuer
+		if domain.Spec.CertificateIssuer != nil {
+			issuer = *domain.Spec.CertificateIssuer
+		}
+
 		canonical := canonicalDomain(domain.Spec.Id)
-		namespace := (*gwapi.Namespace)(ptr.To(domain.GetNamespace()))
-		listeners = append(listeners, listener(canonical, namespace, allowedRoutes, secretKind))
+		listeners = append(listeners, listener(canonical, certNamespacePtr, allowedRoutes, secretKind))
+		secretNames[certSecretName(canonical)] = struct{}{}
+		certRequests = append(certRequests, certificateRequest{hostname: canonical, issuer: issuer})
 		for _, alias := range domain.Spec.Aliases {
-			listeners = append(listeners, listener(alias, namespace, allowedRoutes, secretKind))
+			listeners = append(listeners, listener(alias, certNamespacePtr, allowedRoutes, secretKind))
+			secretNames[certSecretName(alias)] = struct{}{}
+			certRequests = append(certRequests, certificateRequest{hostname: alias, issuer: issuer})
 		}
 	}
 
+	// When certificates live in a different namespace than the Gateway,
+	// Gateway API requires an explicit ReferenceGrant permitting the cross-namespace read.
+	if err = r.reconcileCertificateReferenceGrants(ctx, vimana, certNamespace, secretNames); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Provision a cert-manager Certificate for every hostname whose issuer is configured.
+	if err = r.reconcileCertificates(ctx, vimana, certNamespace, certRequests); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	expectedGateway := &gwapi.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      gatewayNamespacedName.Name,
@@ -242,24 +345,217 @@ func (r *VimanaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
-	// Create or Update the Gateway.
-	err = ensureResourceHasSpec(r.Client, ctx, gatewayNamespacedName, &gwapi.Gateway{}, expectedGateway, gatewaySpecDiffers, gatewayCopySpec)
+	// Create or Update the Gateway, the highest-ranked kind in this reconcile's resource set, once
+	// everything it was built against above (the shared cluster config, the certs/ReferenceGrants
+	// its listeners reference) is in place. ReferenceGrants and Certificates are deliberately left
+	// out of this ranked gate: their own readiness is already surfaced as a separate, non-blocking
+	// status condition (see certificatesReadyCondition) rather than something the Gateway waits on.
+	result, err = applyOrdered(ctx, []orderedStep{
+		{
+			Kind: "Gateway",
+			Apply: func(ctx context.Context) error {
+				return ensureManagedResource(r.Client, ctx, gatewayNamespacedName, &gwapi.Gateway{}, expectedGateway)
+			},
+			Ready: func(ctx context.Context) (readiness.Entry, error) {
+				gateway := &gwapi.Gateway{}
+				if err := r.Get(ctx, gatewayNamespacedName, gateway); err != nil {
+					return readiness.Entry{}, err
+				}
+				return readiness.Check(gateway), nil
+			},
+		},
+	})
+	if err != nil || result.RequeueAfter > 0 {
+		return result, err
+	}
+
+	// Now that the Gateway reflects the desired listeners, bind every GRPCRoute/HTTPRoute in
+	// the namespace against it and publish the resulting route and listener status.
+	gateway := &gwapi.Gateway{}
+	if err = r.Get(ctx, gatewayNamespacedName, gateway); err != nil {
+		logger.Error(err, "Failed to get Gateway for route binding", "namespace", gatewayNamespacedName.Namespace, "name", gatewayNamespacedName.Name)
+		return ctrl.Result{}, err
+	}
+	attachedRoutes, conflictedListeners, err := r.bindRoutes(ctx, gateway)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	applyListenerStatuses(gateway, attachedRoutes, conflictedListeners)
+	if err = r.Status().Update(ctx, gateway); err != nil {
+		logger.Error(err, "Failed to update Gateway status", "namespace", gateway.Namespace, "name", gateway.Name)
+		return ctrl.Result{}, err
+	}
 
-	// TODO: Update conditions, etc.
+	if err = r.updateBindingStatus(ctx, vimana, gateway, conflictedListeners); err != nil {
+		return ctrl.Result{}, err
+	}
 
+	result, err = r.aggregateAvailability(ctx, vimana, gateway)
+	if err != nil {
+		return result, err
+	}
+	if vimana.Spec.RegistryAuth != nil && vimana.Spec.RegistryAuth.Refresher != nil {
+		refresh := vimana.Spec.RegistryAuth.Refresher.Duration
+		if result.RequeueAfter == 0 || refresh < result.RequeueAfter {
+			result.RequeueAfter = refresh
+		}
+	}
+	return result, nil
+}
+
+// finalizeVimana cleans up the external (non-owned) resources for a Vimana that's being
+// deleted, then drops vimanaFinalizer so the deletion can proceed. It's a no-op if the
+// finalizer has already been removed.
+func (r *VimanaReconciler) finalizeVimana(ctx context.Context, vimana *apiv1alpha1.Vimana) error {
+	logger := log.FromContext(ctx)
+
+	if !containsString(vimana.Finalizers, vimanaFinalizer) {
+		return nil
+	}
+
+	gatewayNamespacedName := types.NamespacedName{Name: gatewayName(vimana.Name), Namespace: vimana.Namespace}
+	envoyProxyNamespacedName := types.NamespacedName{Name: gatewayName(vimana.Name), Namespace: gatewayNamespace}
+
+	// Delete in installRank order, reversed (see deleteOrdered): the Gateway before the
+	// EnvoyProxy config it was provisioned against.
+	if err := deleteOrdered(ctx, []deleteStep{
+		{
+			Kind:   "Gateway",
+			Delete: func(ctx context.Context) error { return ensureManagedResourceDeleted(r.Client, ctx, gatewayNamespacedName, &gwapi.Gateway{}) },
+		},
+		{
+			Kind:   "EnvoyProxy",
+			Delete: func(ctx context.Context) error { return ensureResourceDeleted(r.Client, ctx, envoyProxyNamespacedName, &envoygateway.EnvoyProxy{}) },
+		},
+	}); err != nil {
+		return err
+	}
+
+	// Strip this Vimana's imagePullSecret from the default ServiceAccount too: it isn't owned
+	// by the Vimana (it lives in its own namespace, which may outlive it), so it wouldn't
+	// otherwise be cleaned up.
+	if err := r.reconcileDefaultServiceAccountPullSecret(ctx, vimana, ""); err != nil {
+		return err
+	}
+
+	vimana.Finalizers = removeString(vimana.Finalizers, vimanaFinalizer)
+	if err := r.Update(ctx, vimana); err != nil {
+		logger.Error(err, "Failed to remove finalizer from Vimana", "namespace", vimana.Namespace, "name", vimana.Name)
+		return err
+	}
+	return nil
+}
+
+// updateBindingStatus summarizes the Gateway's listener statuses (populated by bindRoutes and
+// applyListenerStatuses) into the Vimana's own conditions, alongside the existing Available
+// condition, so that callers don't have to separately inspect the Gateway to know whether a
+// domain is actually serving traffic.
+func (r *VimanaReconciler) updateBindingStatus(
+	ctx context.Context, vimana *apiv1alpha1.Vimana, gateway *gwapi.Gateway, conflictedListeners map[gwapi.SectionName]bool,
+) error {
+	logger := log.FromContext(ctx)
+
+	listenersProgrammed := metav1.ConditionTrue
+	programmedReason, programmedMessage := "Programmed", "All listeners programmed"
+	if len(conflictedListeners) > 0 {
+		listenersProgrammed = metav1.ConditionFalse
+		programmedReason, programmedMessage = reasonHostnameConflict, fmt.Sprintf("%d listener(s) have a conflicting hostname", len(conflictedListeners))
+	}
+
+	var unbound int32
+	for _, listenerStatus := range gateway.Status.Listeners {
+		if listenerStatus.AttachedRoutes == 0 && !conflictedListeners[listenerStatus.Name] {
+			unbound++
+		}
+	}
+	routesBound := metav1.ConditionTrue
+	boundReason, boundMessage := "Bound", "Every listener has at least 1 route attached"
+	if unbound > 0 {
+		routesBound = metav1.ConditionFalse
+		boundReason, boundMessage = "NoRoutesAttached", fmt.Sprintf("%d listener(s) have no attached routes", unbound)
+	}
+
+	meta.SetStatusCondition(&vimana.Status.Conditions, metav1.Condition{
+		Type: "ListenersProgrammed", Status: listenersProgrammed, Reason: programmedReason, Message: programmedMessage,
+	})
+	meta.SetStatusCondition(&vimana.Status.Conditions, metav1.Condition{
+		Type: "RoutesBound", Status: routesBound, Reason: boundReason, Message: boundMessage,
+	})
+
+	certificatesReady, err := r.certificatesReadyCondition(ctx, vimana)
+	if err != nil {
+		return err
+	}
+	meta.SetStatusCondition(&vimana.Status.Conditions, certificatesReady)
+
+	if err := r.Status().Update(ctx, vimana); err != nil {
+		logger.Error(err, "Failed to update Vimana binding status", "namespace", vimana.Namespace, "name", vimana.Name)
+		return err
+	}
+	return nil
+}
+
+// aggregateAvailability is a Helm 3-style readiness gate, run at the end of every reconcile: it
+// runs the pkg/readiness Checker for the Gateway plus every Deployment and Service in the
+// namespace (every one of which belongs to this Vimana, since only a single Vimana is permitted
+// per namespace), and only reports Available=True once they're all Ready. While any of them
+// aren't, Available=False carries a Message naming the specific unready children, and the Vimana
+// is requeued after readinessRequeueDelay until they converge.
+func (r *VimanaReconciler) aggregateAvailability(ctx context.Context, vimana *apiv1alpha1.Vimana, gateway *gwapi.Gateway) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	report := readiness.Report{Entries: []readiness.Entry{readiness.Check(gateway)}}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(vimana.Namespace)); err != nil {
+		logger.Error(err, "Failed to list Deployments for readiness check", "namespace", vimana.Namespace)
+		return ctrl.Result{}, err
+	}
+	for i := range deployments.Items {
+		report.Entries = append(report.Entries, readiness.Check(&deployments.Items[i]))
+	}
+
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(vimana.Namespace)); err != nil {
+		logger.Error(err, "Failed to list Services for readiness check", "namespace", vimana.Namespace)
+		return ctrl.Result{}, err
+	}
+	for i := range services.Items {
+		report.Entries = append(report.Entries, readiness.Check(&services.Items[i]))
+	}
+
+	status, reason, message := metav1.ConditionTrue, "Reconciled", "Successfully reconciled vimana"
+	if !report.Ready() {
+		status, reason, message = metav1.ConditionFalse, "ResourcesNotReady", report.Message()
+	}
+	if err := updateAvailabilityStatus(r.Client, ctx, vimana, status, reason, message); err != nil {
+		return ctrl.Result{}, err
+	}
+	if status != metav1.ConditionTrue {
+		return ctrl.Result{RequeueAfter: readinessRequeueDelay}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
-// Return the Gateway Listener object for the given domain name in the given namespace.
+// Return the hex-encoded SHA-256 hash of a domain name,
+// used to derive both its Listener name and its certificate Secret name.
+func domainHash(domain string) string {
+	hash := sha256.Sum256([]byte(domain))
+	return hex.EncodeToString(hash[:])
+}
+
+// Return the expected name of the certificate Secret for the given domain name.
+// Guaranteed valid and *probably* unique per domain.
+func certSecretName(domain string) string {
+	return fmt.Sprintf("c-%s", domainHash(domain))
+}
+
+// Return the Gateway Listener object for the given domain name,
+// whose certificate Secret is expected to live in the given namespace.
 // This will have a specific name that looks like `l-<hash>` with the hex-encoded SHA-256 hash of the domain name;
 // guaranteed valid and *probably* unique per domain.
-// The associated certificate is expected to have the name `c-<hash>` for the same reasons.
-func listener(domain string, namespace *gwapi.Namespace, allowedRoutes *gwapi.AllowedRoutes, secretKind *gwapi.Kind) gwapi.Listener {
-	hash := sha256.Sum256([]byte(domain))
-	hashHex := hex.EncodeToString(hash[:])
+func listener(domain string, certNamespace *gwapi.Namespace, allowedRoutes *gwapi.AllowedRoutes, secretKind *gwapi.Kind) gwapi.Listener {
+	hashHex := domainHash(domain)
 	return gwapi.Listener{
 		Name:     gwapi.SectionName(fmt.Sprintf("l-%s", hashHex)),
 		Protocol: gwapi.HTTPSProtocolType,
@@ -269,8 +565,8 @@ func listener(domain string, namespace *gwapi.Namespace, allowedRoutes *gwapi.Al
 			CertificateRefs: []gwapi.SecretObjectReference{
 				{
 					Kind:      secretKind,
-					Name:      gwapi.ObjectName(fmt.Sprintf("c-%s", hashHex)),
-					Namespace: namespace,
+					Name:      gwapi.ObjectName(certSecretName(domain)),
+					Namespace: certNamespace,
 				},
 			},
 		},
@@ -278,6 +574,216 @@ func listener(domain string, namespace *gwapi.Namespace, allowedRoutes *gwapi.Al
 	}
 }
 
+// reconcileCertificateReferenceGrants ensures that exactly the ReferenceGrants required for
+// this Vimana's Gateway to read its listener certificate Secrets exist.
+// If certNamespace is the same as the Vimana's own namespace, no ReferenceGrant is needed
+// (the Gateway can read Secrets in its own namespace unconditionally), and any grants left
+// over from a previous configuration are removed.
+// secretNames may be nil, in which case every grant owned by this Vimana is removed.
+func (r *VimanaReconciler) reconcileCertificateReferenceGrants(
+	ctx context.Context, vimana *apiv1alpha1.Vimana, certNamespace string, secretNames map[string]struct{},
+) error {
+	logger := log.FromContext(ctx)
+
+	// Identifies the ReferenceGrants owned by this Vimana, wherever they may currently live.
+	ownerLabel := hashed(fmt.Sprintf("%s/%s", vimana.Namespace, vimana.Name))
+	existing := &gwapibeta.ReferenceGrantList{}
+	if err := r.List(ctx, existing, client.MatchingLabels{labelReferenceGrantOwnerKey: ownerLabel}); err != nil {
+		logger.Error(err, "Failed to list ReferenceGrants", "vimana", vimana.Name)
+		return err
+	}
+
+	wanted := map[string]*gwapibeta.ReferenceGrant{}
+	if certNamespace != vimana.Namespace {
+		for secretName := range secretNames {
+			name := prefixed(hashed(fmt.Sprintf("%s/%s", vimana.Namespace, secretName)), 'r')
+			wanted[name] = &gwapibeta.ReferenceGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: certNamespace,
+					Labels:    map[string]string{labelReferenceGrantOwnerKey: ownerLabel},
+				},
+				Spec: gwapibeta.ReferenceGrantSpec{
+					From: []gwapibeta.ReferenceGrantFrom{
+						{
+							Group:     gwapibeta.Group("gateway.networking.k8s.io"),
+							Kind:      gwapibeta.Kind("Gateway"),
+							Namespace: gwapibeta.Namespace(vimana.Namespace),
+						},
+					},
+					To: []gwapibeta.ReferenceGrantTo{
+						{
+							Kind: gwapibeta.Kind("Secret"),
+							Name: (*gwapibeta.ObjectName)(ptr.To(secretName)),
+						},
+					},
+				},
+			}
+		}
+	}
+
+	for name, expected := range wanted {
+		namespacedName := types.NamespacedName{Name: name, Namespace: certNamespace}
+		err := ensureResourceHasSpecAndLabels(
+			r.Client, ctx, namespacedName, &gwapibeta.ReferenceGrant{}, expected, referenceGrantSpecDiffers, referenceGrantCopySpec,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete any grant that is no longer wanted, including ones left behind in a namespace
+	// that is no longer this Vimana's CertificateNamespace.
+	for i := range existing.Items {
+		grant := &existing.Items[i]
+		if expected, ok := wanted[grant.Name]; ok && grant.Namespace == expected.Namespace {
+			continue
+		}
+		if err := r.Delete(ctx, grant); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete stale ReferenceGrant", "namespace", grant.Namespace, "name", grant.Name)
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileRegistryAuth keeps the resources backing vimana.Spec.RegistryAuth in sync: the
+// dockerconfigjson Secret materialized from Username/Password (a SecretRef is used as-is and
+// never written to), and the namespace's default ServiceAccount's imagePullSecrets, so that Pods
+// created outside this operator's own Deployments can still pull from Spec.Registry.
+func (r *VimanaReconciler) reconcileRegistryAuth(ctx context.Context, vimana *apiv1alpha1.Vimana) error {
+	logger := log.FromContext(ctx)
+
+	materializedNamespacedName := types.NamespacedName{Name: registryAuthSecretName(vimana.Name), Namespace: vimana.Namespace}
+	if vimana.Spec.RegistryAuth != nil && vimana.Spec.RegistryAuth.SecretRef == nil {
+		dockerConfigJson, err := r.buildDockerConfigJson(ctx, vimana)
+		if err != nil {
+			return err
+		}
+		expected := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: materializedNamespacedName.Name, Namespace: materializedNamespacedName.Namespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: dockerConfigJson},
+		}
+		if err := ctrl.SetControllerReference(vimana, expected, r.Scheme); err != nil {
+			logger.Error(err, "Failed to set owner reference for registry auth Secret", "namespace", expected.Namespace, "name", expected.Name)
+			return err
+		}
+		if err := ensureManagedResource(r.Client, ctx, materializedNamespacedName, &corev1.Secret{}, expected); err != nil {
+			return err
+		}
+	} else if err := ensureManagedResourceDeleted(r.Client, ctx, materializedNamespacedName, &corev1.Secret{}); err != nil {
+		// Either RegistryAuth is unset, or it now points at an existing Secret directly, so any
+		// Secret this controller previously materialized under a prior configuration is stale.
+		return err
+	}
+
+	return r.reconcileDefaultServiceAccountPullSecret(ctx, vimana, registryPullSecretName(vimana))
+}
+
+// registryAuthSecretReadiness reports the readiness of the dockerconfigjson Secret
+// reconcileRegistryAuth materializes, for use as an orderedStep's Ready func. If RegistryAuth is
+// unset, or points directly at a caller-supplied SecretRef instead, no Secret is materialized in
+// this configuration, so there's nothing to wait on.
+func (r *VimanaReconciler) registryAuthSecretReadiness(ctx context.Context, vimana *apiv1alpha1.Vimana) (readiness.Entry, error) {
+	if vimana.Spec.RegistryAuth == nil || vimana.Spec.RegistryAuth.SecretRef != nil {
+		return readiness.Entry{
+			Kind: "Secret", Name: registryAuthSecretName(vimana.Name),
+			Readiness: readiness.Readiness{Ready: true, Reason: "NotManaged", Message: "No RegistryAuth Secret is materialized in this configuration"},
+		}, nil
+	}
+	secret := &corev1.Secret{}
+	namespacedName := types.NamespacedName{Name: registryAuthSecretName(vimana.Name), Namespace: vimana.Namespace}
+	if err := r.Get(ctx, namespacedName, secret); err != nil {
+		return readiness.Entry{}, err
+	}
+	return readiness.Check(secret), nil
+}
+
+// clusterResourceReadiness reports the readiness of the cluster-scoped resource named name, for
+// use as an orderedStep's Ready func.
+func (r *VimanaReconciler) clusterResourceReadiness(ctx context.Context, name string, obj client.Object) (readiness.Entry, error) {
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+		return readiness.Entry{}, err
+	}
+	return readiness.Check(obj), nil
+}
+
+// buildDockerConfigJson reads the keys vimana.Spec.RegistryAuth.Username and .Password point at
+// and assembles a .dockerconfigjson document scoped to Spec.Registry, the format
+// corev1.SecretTypeDockerConfigJson Secrets expect.
+func (r *VimanaReconciler) buildDockerConfigJson(ctx context.Context, vimana *apiv1alpha1.Vimana) ([]byte, error) {
+	logger := log.FromContext(ctx)
+
+	username, err := r.registryAuthSecretKey(ctx, vimana.Namespace, vimana.Spec.RegistryAuth.Username)
+	if err != nil {
+		logger.Error(err, "Failed to read registry auth username", "namespace", vimana.Namespace, "name", vimana.Name)
+		return nil, err
+	}
+	password, err := r.registryAuthSecretKey(ctx, vimana.Namespace, vimana.Spec.RegistryAuth.Password)
+	if err != nil {
+		logger.Error(err, "Failed to read registry auth password", "namespace", vimana.Namespace, "name", vimana.Name)
+		return nil, err
+	}
+
+	return json.Marshal(map[string]any{
+		"auths": map[string]any{
+			vimana.Spec.Registry: map[string]string{
+				"username": username,
+				"password": password,
+				"auth":     base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password))),
+			},
+		},
+	})
+}
+
+// registryAuthSecretKey reads the value of a single key out of a Secret in namespace.
+func (r *VimanaReconciler) registryAuthSecretKey(ctx context.Context, namespace string, selector *corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret); err != nil {
+		return "", err
+	}
+	return string(secret.Data[selector.Key]), nil
+}
+
+// reconcileDefaultServiceAccountPullSecret makes sure the namespace's default ServiceAccount
+// carries secretName (if non-empty) among its imagePullSecrets, and no longer carries a stale
+// entry for this Vimana's own materialized Secret. It's a no-op if the default ServiceAccount
+// doesn't exist yet; whatever creates it will re-trigger this Vimana's reconcile.
+func (r *VimanaReconciler) reconcileDefaultServiceAccountPullSecret(ctx context.Context, vimana *apiv1alpha1.Vimana, secretName string) error {
+	logger := log.FromContext(ctx)
+
+	serviceAccount := &corev1.ServiceAccount{}
+	namespacedName := types.NamespacedName{Name: defaultServiceAccountName, Namespace: vimana.Namespace}
+	if err := r.Get(ctx, namespacedName, serviceAccount); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error(err, "Failed to get default ServiceAccount", "namespace", vimana.Namespace)
+		return err
+	}
+
+	managed := registryAuthSecretName(vimana.Name)
+	var pullSecrets []corev1.LocalObjectReference
+	for _, ref := range serviceAccount.ImagePullSecrets {
+		if ref.Name != managed && ref.Name != secretName {
+			pullSecrets = append(pullSecrets, ref)
+		}
+	}
+	if secretName != "" {
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: secretName})
+	}
+	if reflect.DeepEqual(pullSecrets, serviceAccount.ImagePullSecrets) {
+		return nil
+	}
+	serviceAccount.ImagePullSecrets = pullSecrets
+	if err := r.Update(ctx, serviceAccount); err != nil {
+		logger.Error(err, "Failed to update default ServiceAccount imagePullSecrets", "namespace", vimana.Namespace)
+		return err
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *VimanaReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -6,12 +6,18 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+	gwapibeta "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	nodev1 "k8s.io/api/node/v1"
 	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
@@ -62,6 +68,15 @@ var _ = Describe("Vimana Controller", func() {
 
 			By("Cleanup the specific resource instance Vimana")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			// vimanaFinalizer blocks actual removal until a Reconcile observes the
+			// DeletionTimestamp and cleans up; since these tests drive Reconcile by hand,
+			// do that once more so the next It's BeforeEach sees a clean slate.
+			if err = k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				controllerReconciler := &VimanaReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+			}
 		})
 
 		It("should successfully reconcile the resource with no domains", func() {
@@ -118,7 +133,7 @@ var _ = Describe("Vimana Controller", func() {
 			// Verify status conditions
 			err = k8sClient.Get(ctx, typeNamespacedName, vimana)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(vimana.Status.Conditions).To(HaveLen(1))
+			Expect(vimana.Status.Conditions).To(HaveLen(4))
 			condition := vimana.Status.Conditions[0]
 			Expect(condition).To(Equal(metav1.Condition{
 				Type:               "Available",
@@ -128,6 +143,17 @@ var _ = Describe("Vimana Controller", func() {
 				LastTransitionTime: condition.LastTransitionTime, // non-deterministic
 			}))
 
+			By("Verifying the ListenersProgrammed and RoutesBound conditions")
+			listenersProgrammed := meta.FindStatusCondition(vimana.Status.Conditions, "ListenersProgrammed")
+			Expect(listenersProgrammed).NotTo(BeNil())
+			Expect(listenersProgrammed.Status).To(Equal(metav1.ConditionTrue))
+			routesBound := meta.FindStatusCondition(vimana.Status.Conditions, "RoutesBound")
+			Expect(routesBound).NotTo(BeNil())
+			Expect(routesBound.Status).To(Equal(metav1.ConditionFalse), "no GRPCRoutes exist yet, so no listener has an attached route")
+			certificatesReady := meta.FindStatusCondition(vimana.Status.Conditions, "CertificatesReady")
+			Expect(certificatesReady).NotTo(BeNil())
+			Expect(certificatesReady.Status).To(Equal(metav1.ConditionTrue), "no CertificateIssuer is configured, so no Certificates are managed")
+
 			err = k8sClient.Get(ctx, types.NamespacedName{
 				Name: runtimeClassName,
 			}, runtimeClass)
@@ -274,5 +300,306 @@ var _ = Describe("Vimana Controller", func() {
 				},
 			))
 		})
+
+		It("should maintain a specific ReferenceGrant per certificate Secret when CertificateNamespace is set (mirrors GatewaySecretReferenceGrantSpecific)", func() {
+			const certVimanaName = "cert-ns-vimana"
+			const certNamespace = "tls"
+
+			By("creating a Vimana whose certificates live in a shared namespace")
+			certVimana := &apiv1alpha1.Vimana{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      certVimanaName,
+					Namespace: namespace,
+				},
+				Spec: apiv1alpha1.VimanaSpec{
+					Regions:              vimanaRegions,
+					CertificateNamespace: certNamespace,
+				},
+			}
+			Expect(k8sClient.Create(ctx, certVimana)).To(Succeed())
+
+			domain := &apiv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      certVimanaName,
+					Namespace: namespace,
+				},
+				Spec: apiv1alpha1.DomainSpec{
+					Id:      domainId,
+					Vimana:  certVimanaName,
+					Aliases: domainAliases,
+				},
+			}
+			Expect(k8sClient.Create(ctx, domain)).To(Succeed())
+
+			controllerReconciler := &VimanaReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: certVimanaName, Namespace: namespace},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			grants := &gwapibeta.ReferenceGrantList{}
+			Expect(k8sClient.List(ctx, grants, client.InNamespace(certNamespace))).To(Succeed())
+			Expect(grants.Items).To(HaveLen(3), "expected 1 grant per distinct certificate Secret (canonical + 2 aliases)")
+			for _, grant := range grants.Items {
+				Expect(grant.Spec.From).To(Equal([]gwapibeta.ReferenceGrantFrom{
+					{
+						Group:     gwapibeta.Group("gateway.networking.k8s.io"),
+						Kind:      gwapibeta.Kind("Gateway"),
+						Namespace: gwapibeta.Namespace(namespace),
+					},
+				}))
+				Expect(grant.Spec.To).To(HaveLen(1))
+				Expect(grant.Spec.To[0].Kind).To(Equal(gwapibeta.Kind("Secret")))
+				Expect(grant.Spec.To[0].Name).NotTo(BeNil())
+			}
+
+			By("deleting the domain, which should drop the now-unreferenced ReferenceGrants")
+			Expect(k8sClient.Delete(ctx, domain)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: certVimanaName, Namespace: namespace},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.List(ctx, grants, client.InNamespace(certNamespace))).To(Succeed())
+			Expect(grants.Items).To(BeEmpty())
+
+			Expect(k8sClient.Delete(ctx, certVimana)).To(Succeed())
+		})
+
+		It("should bind a matching GRPCRoute and reject one with no matching listener hostname", func() {
+			By("creating the gateway")
+			controllerReconciler := &VimanaReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			domain := &apiv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: apiv1alpha1.DomainSpec{
+					Id:      domainId,
+					Aliases: domainAliases,
+				},
+			}
+			Expect(k8sClient.Create(ctx, domain)).To(Succeed())
+
+			matchingRoute := &gwapi.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "matching-route",
+					Namespace: namespace,
+				},
+				Spec: gwapi.GRPCRouteSpec{
+					CommonRouteSpec: gwapi.CommonRouteSpec{
+						ParentRefs: []gwapi.ParentReference{
+							{Name: gwapi.ObjectName(gatewayName)},
+						},
+					},
+					Hostnames: []gwapi.Hostname{gwapi.Hostname(domainId + ".app.vimana.host")},
+				},
+			}
+			Expect(k8sClient.Create(ctx, matchingRoute)).To(Succeed())
+
+			unmatchedRoute := &gwapi.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unmatched-route",
+					Namespace: namespace,
+				},
+				Spec: gwapi.GRPCRouteSpec{
+					CommonRouteSpec: gwapi.CommonRouteSpec{
+						ParentRefs: []gwapi.ParentReference{
+							{Name: gwapi.ObjectName(gatewayName)},
+						},
+					},
+					Hostnames: []gwapi.Hostname{"unrelated.example.org"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, unmatchedRoute)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the matching route was accepted")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "matching-route", Namespace: namespace}, matchingRoute)).To(Succeed())
+			Expect(matchingRoute.Status.Parents).To(HaveLen(1))
+			acceptedCondition := meta.FindStatusCondition(matchingRoute.Status.Parents[0].Conditions, string(gwapi.RouteConditionAccepted))
+			Expect(acceptedCondition).NotTo(BeNil())
+			Expect(acceptedCondition.Status).To(Equal(metav1.ConditionTrue))
+
+			By("verifying the unmatched route was rejected with NoMatchingListenerHostname")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "unmatched-route", Namespace: namespace}, unmatchedRoute)).To(Succeed())
+			Expect(unmatchedRoute.Status.Parents).To(HaveLen(1))
+			rejectedCondition := meta.FindStatusCondition(unmatchedRoute.Status.Parents[0].Conditions, string(gwapi.RouteConditionAccepted))
+			Expect(rejectedCondition).NotTo(BeNil())
+			Expect(rejectedCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(rejectedCondition.Reason).To(Equal(reasonNoMatchingListenerHostname))
+
+			By("verifying the Gateway's listener status reflects the attached route count")
+			gateway := &gwapi.Gateway{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: gatewayName, Namespace: namespace}, gateway)).To(Succeed())
+			var canonicalListenerStatus *gwapi.ListenerStatus
+			for i := range gateway.Status.Listeners {
+				if string(*gateway.Spec.Listeners[i].Hostname) == domainId+".app.vimana.host" {
+					canonicalListenerStatus = &gateway.Status.Listeners[i]
+				}
+			}
+			Expect(canonicalListenerStatus).NotTo(BeNil())
+			Expect(canonicalListenerStatus.AttachedRoutes).To(Equal(int32(1)))
+
+			Expect(k8sClient.Delete(ctx, matchingRoute)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, unmatchedRoute)).To(Succeed())
+		})
+
+		It("should provision a Certificate per hostname when an issuer is configured, and respect pre-existing Secrets", func() {
+			const certIssuerVimanaName = "cert-issuer-vimana"
+			issuerRef := cmmeta.ObjectReference{Name: "self-signed", Kind: "ClusterIssuer"}
+
+			By("creating a Vimana with a default CertificateIssuer")
+			certIssuerVimana := &apiv1alpha1.Vimana{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      certIssuerVimanaName,
+					Namespace: namespace,
+				},
+				Spec: apiv1alpha1.VimanaSpec{
+					Regions:           vimanaRegions,
+					CertificateIssuer: issuerRef,
+				},
+			}
+			Expect(k8sClient.Create(ctx, certIssuerVimana)).To(Succeed())
+
+			By("pre-populating the alias's certificate Secret, as if the caller brought their own")
+			aliasSecretName := certSecretName(domainAliases[0])
+			preExistingSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      aliasSecretName,
+					Namespace: namespace,
+				},
+				Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+			}
+			Expect(k8sClient.Create(ctx, preExistingSecret)).To(Succeed())
+
+			domain := &apiv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      certIssuerVimanaName,
+					Namespace: namespace,
+				},
+				Spec: apiv1alpha1.DomainSpec{
+					Id:      domainId,
+					Vimana:  certIssuerVimanaName,
+					Aliases: domainAliases,
+				},
+			}
+			Expect(k8sClient.Create(ctx, domain)).To(Succeed())
+
+			controllerReconciler := &VimanaReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: certIssuerVimanaName, Namespace: namespace},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying a Certificate was created for the canonical hostname")
+			canonicalCertificate := &cmapi.Certificate{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      certSecretName(canonicalDomain(domainId)),
+				Namespace: namespace,
+			}, canonicalCertificate)).To(Succeed())
+			Expect(canonicalCertificate.Spec.DNSNames).To(Equal([]string{canonicalDomain(domainId)}))
+			Expect(canonicalCertificate.Spec.IssuerRef).To(Equal(issuerRef))
+
+			By("verifying no Certificate was created for the alias whose Secret pre-existed")
+			aliasCertificate := &cmapi.Certificate{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: aliasSecretName, Namespace: namespace}, aliasCertificate)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "expected no Certificate to be created for a pre-existing Secret")
+
+			By("deleting the domain, which should delete the Certificate it owns")
+			Expect(k8sClient.Delete(ctx, domain)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: certIssuerVimanaName, Namespace: namespace},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, types.NamespacedName{
+				Name:      certSecretName(canonicalDomain(domainId)),
+				Namespace: namespace,
+			}, canonicalCertificate)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "expected the Certificate to be deleted along with its domain")
+
+			Expect(k8sClient.Delete(ctx, preExistingSecret)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, certIssuerVimana)).To(Succeed())
+		})
+
+		It("should materialize a dockerconfigjson Secret from RegistryAuth and inject it into the default ServiceAccount", func() {
+			const registryAuthVimanaName = "registry-auth-vimana"
+
+			By("creating a Secret holding the registry credentials")
+			credsSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: namespace},
+				Data:       map[string][]byte{"username": []byte("alice"), "password": []byte("hunter2")},
+			}
+			Expect(k8sClient.Create(ctx, credsSecret)).To(Succeed())
+
+			By("creating the default ServiceAccount, as Kubernetes would")
+			defaultServiceAccount := &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultServiceAccountName, Namespace: namespace},
+			}
+			Expect(k8sClient.Create(ctx, defaultServiceAccount)).To(Succeed())
+
+			By("creating a Vimana with an inline RegistryAuth")
+			registryAuthVimana := &apiv1alpha1.Vimana{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      registryAuthVimanaName,
+					Namespace: namespace,
+				},
+				Spec: apiv1alpha1.VimanaSpec{
+					Regions:  vimanaRegions,
+					Registry: "registry.example.com",
+					RegistryAuth: &apiv1alpha1.RegistryAuth{
+						Username: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: credsSecret.Name}, Key: "username"},
+						Password: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: credsSecret.Name}, Key: "password"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, registryAuthVimana)).To(Succeed())
+
+			controllerReconciler := &VimanaReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: registryAuthVimanaName, Namespace: namespace},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the dockerconfigjson Secret was materialized")
+			pullSecretName := registryAuthSecretName(registryAuthVimanaName)
+			pullSecret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pullSecretName, Namespace: namespace}, pullSecret)).To(Succeed())
+			Expect(pullSecret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+			Expect(pullSecret.Data[corev1.DockerConfigJsonKey]).To(ContainSubstring("registry.example.com"))
+
+			By("verifying the default ServiceAccount carries the pull secret")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: defaultServiceAccountName, Namespace: namespace}, defaultServiceAccount)).To(Succeed())
+			Expect(defaultServiceAccount.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: pullSecretName}}))
+
+			By("deleting the Vimana, which should strip the pull secret from the default ServiceAccount")
+			Expect(k8sClient.Delete(ctx, registryAuthVimana)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: registryAuthVimanaName, Namespace: namespace},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: defaultServiceAccountName, Namespace: namespace}, defaultServiceAccount)).To(Succeed())
+			Expect(defaultServiceAccount.ImagePullSecrets).To(BeEmpty())
+
+			Expect(k8sClient.Delete(ctx, defaultServiceAccount)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, credsSecret)).To(Succeed())
+		})
 	})
 })
@@ -0,0 +1,355 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+	"vimana.host/operator/pkg/readiness"
+)
+
+// defaultRolloutTimeout is used in place of Strategy.Timeout when it's left unset.
+const defaultRolloutTimeout = 10 * time.Minute
+
+// VimanaRolloutReconciler reconciles a VimanaRollout object.
+//
+// A VimanaRollout walks Spec.TargetVersion through Spec.Order one region at a time. "Region"
+// here means the same thing it means to Domain.Spec.Regions: a Domain is promoted at the first
+// Order entry that appears in its own Spec.Regions, or immediately (at Order's first entry) if
+// it has no Spec.Regions of its own. There's no per-region dimension on Component/Deployment to
+// relabel, so "promoting a region" concretely means shifting every Server.Spec.VersionWeights
+// under that region's Domains toward TargetVersion; see applyVersionWeight.
+type VimanaRolloutReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=api.vimana.host,resources=vimanarollouts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=api.vimana.host,resources=vimanarollouts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=api.vimana.host,resources=domains,verbs=get;list;watch
+// +kubebuilder:rbac:groups=api.vimana.host,resources=servers,verbs=get;list;watch;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *VimanaRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	rollout := &apiv1alpha1.VimanaRollout{}
+	if err := r.Get(ctx, req.NamespacedName, rollout); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("VimanaRollout not found, assumed deleted", "namespace", req.Namespace, "name", req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get VimanaRollout", "namespace", req.Namespace, "name", req.Name)
+		return ctrl.Result{}, err
+	}
+
+	domains := &apiv1alpha1.DomainList{}
+	if err := r.List(ctx, domains, client.InNamespace(req.Namespace)); err != nil {
+		logger.Error(err, "Failed to list Domains", "namespace", req.Namespace)
+		return ctrl.Result{}, err
+	}
+	var owned []apiv1alpha1.Domain
+	for _, domain := range domains.Items {
+		if domain.Spec.Vimana == rollout.Spec.VimanaRef.Name {
+			owned = append(owned, domain)
+		}
+	}
+
+	order := rollout.Spec.Order
+	if len(order) == 0 {
+		// No regions configured: promote every Domain in a single step.
+		order = []string{""}
+	}
+
+	if rollout.Status.CurrentRegion == "" && rollout.Status.StepStartedAt == nil {
+		return ctrl.Result{}, r.advanceTo(ctx, rollout, order[0], 0)
+	}
+
+	currentIndex := indexOf(order, rollout.Status.CurrentRegion)
+	if currentIndex < 0 {
+		// CurrentRegion no longer appears in Order (e.g. Spec.Order was edited mid-rollout).
+		// Restart from the top rather than guessing where it should resume.
+		return ctrl.Result{}, r.advanceTo(ctx, rollout, order[0], 0)
+	}
+
+	current := owned
+	if len(rollout.Spec.Order) > 0 {
+		current = domainsForRegion(owned, order, currentIndex)
+	}
+	weight := stepWeight(rollout.Spec.Strategy, rollout.Status.CurrentStep)
+
+	skipped := 0
+	for _, domain := range current {
+		servers := &apiv1alpha1.ServerList{}
+		if err := r.List(ctx, servers, client.InNamespace(req.Namespace), client.MatchingLabels{labelDomainKey: domain.Spec.Id}); err != nil {
+			logger.Error(err, "Failed to list Servers", "namespace", req.Namespace, "domain", domain.Spec.Id)
+			return ctrl.Result{}, err
+		}
+		for i := range servers.Items {
+			server := &servers.Items[i]
+			weights, ok := applyVersionWeight(server.Spec.VersionWeights, rollout.Spec.TargetVersion, weight)
+			if !ok {
+				skipped++
+				continue
+			}
+			if !weightsEqual(server.Spec.VersionWeights, weights) {
+				server.Spec.VersionWeights = weights
+				if err := r.Update(ctx, server); err != nil {
+					logger.Error(err, "Failed to update Server VersionWeights", "namespace", req.Namespace, "name", server.Name)
+					return ctrl.Result{}, err
+				}
+			}
+		}
+	}
+	if skipped > 0 {
+		logger.Info("Skipped Servers already running more than 1 non-target version", "namespace", req.Namespace, "name", rollout.Name, "count", skipped)
+	}
+
+	report, err := regionReadiness(ctx, r.Client, req.Namespace, current, rollout.Spec.TargetVersion)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !report.Ready() {
+		return r.handleNotReady(ctx, rollout, current, weight, report.Message())
+	}
+	return r.handleReady(ctx, rollout, order, currentIndex)
+}
+
+// handleReady advances the rollout once the current step's Deployments report Ready: to the
+// next Canary step within the same region, or to the next region, or to completion.
+func (r *VimanaRolloutReconciler) handleReady(ctx context.Context, rollout *apiv1alpha1.VimanaRollout, order []string, currentIndex int) (ctrl.Result, error) {
+	steps := rollout.Spec.Strategy.Steps
+	if rollout.Spec.Strategy.Type == apiv1alpha1.RolloutStrategyCanary && int(rollout.Status.CurrentStep) < len(steps)-1 {
+		if !pauseElapsed(rollout.Status.StepStartedAt, steps[rollout.Status.CurrentStep].Pause) {
+			return ctrl.Result{RequeueAfter: readinessRequeueDelay}, r.setCondition(ctx, rollout, "RolloutProgressing", metav1.ConditionTrue, "Paused", "Holding at the current step's Pause before advancing")
+		}
+		return ctrl.Result{RequeueAfter: readinessRequeueDelay}, r.advanceTo(ctx, rollout, order[currentIndex], rollout.Status.CurrentStep+1)
+	}
+
+	if currentIndex+1 >= len(order) {
+		if err := r.setCondition(ctx, rollout, "RolloutProgressing", metav1.ConditionFalse, "Complete", "Every region has been promoted to the target version"); err != nil {
+			return ctrl.Result{}, err
+		}
+		rollout.Status.CurrentRegion = ""
+		rollout.Status.CurrentStep = 0
+		return ctrl.Result{}, r.Status().Update(ctx, rollout)
+	}
+	return ctrl.Result{RequeueAfter: readinessRequeueDelay}, r.advanceTo(ctx, rollout, order[currentIndex+1], 0)
+}
+
+// handleNotReady reports Progressing while waiting for the current step to come up, or flips to
+// Degraded (optionally rolling the step's weight back to 0) once Strategy.Timeout has elapsed.
+func (r *VimanaRolloutReconciler) handleNotReady(ctx context.Context, rollout *apiv1alpha1.VimanaRollout, current []apiv1alpha1.Domain, weight int32, message string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !timeoutElapsed(rollout.Status.StepStartedAt, rollout.Spec.Strategy.Timeout) {
+		return ctrl.Result{RequeueAfter: readinessRequeueDelay}, r.setCondition(ctx, rollout, "RolloutProgressing", metav1.ConditionTrue, "Waiting", message)
+	}
+
+	if rollout.Spec.Strategy.AutoRollback {
+		for _, domain := range current {
+			servers := &apiv1alpha1.ServerList{}
+			if err := r.List(ctx, servers, client.InNamespace(rollout.Namespace), client.MatchingLabels{labelDomainKey: domain.Spec.Id}); err != nil {
+				logger.Error(err, "Failed to list Servers for rollback", "namespace", rollout.Namespace, "domain", domain.Spec.Id)
+				return ctrl.Result{}, err
+			}
+			for i := range servers.Items {
+				server := &servers.Items[i]
+				weights, ok := applyVersionWeight(server.Spec.VersionWeights, rollout.Spec.TargetVersion, 0)
+				if !ok || weightsEqual(server.Spec.VersionWeights, weights) {
+					continue
+				}
+				server.Spec.VersionWeights = weights
+				if err := r.Update(ctx, server); err != nil {
+					logger.Error(err, "Failed to roll back Server VersionWeights", "namespace", rollout.Namespace, "name", server.Name)
+					return ctrl.Result{}, err
+				}
+			}
+		}
+	}
+
+	return ctrl.Result{}, r.setCondition(ctx, rollout, "RolloutDegraded", metav1.ConditionTrue, "Timeout",
+		fmt.Sprintf("Region %q did not become Ready within its Strategy.Timeout: %s", rollout.Status.CurrentRegion, message))
+}
+
+// advanceTo moves the rollout to region/step and resets StepStartedAt, so the next
+// Timeout/Pause check is measured from now.
+func (r *VimanaRolloutReconciler) advanceTo(ctx context.Context, rollout *apiv1alpha1.VimanaRollout, region string, step int32) error {
+	now := metav1.Now()
+	rollout.Status.CurrentRegion = region
+	rollout.Status.CurrentStep = step
+	rollout.Status.StepStartedAt = &now
+	meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+		Type: "RolloutProgressing", Status: metav1.ConditionTrue, Reason: "Advancing",
+		Message: fmt.Sprintf("Promoting region %q at step %d", region, step),
+	})
+	meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+		Type: "RolloutDegraded", Status: metav1.ConditionFalse, Reason: "Advancing", Message: "Advanced past the previously degraded step",
+	})
+	return r.Status().Update(ctx, rollout)
+}
+
+func (r *VimanaRolloutReconciler) setCondition(ctx context.Context, rollout *apiv1alpha1.VimanaRollout, conditionType string, status metav1.ConditionStatus, reason, message string) error {
+	meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{Type: conditionType, Status: status, Reason: reason, Message: message})
+	return r.Status().Update(ctx, rollout)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VimanaRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1alpha1.VimanaRollout{}).
+		Complete(r)
+}
+
+// domainsForRegion returns the Domains among owned that are promoted at order[index]: those
+// for which order[index] is the first Order entry their Spec.Regions names, plus (only when
+// index is 0) those with no Spec.Regions of their own.
+func domainsForRegion(owned []apiv1alpha1.Domain, order []string, index int) []apiv1alpha1.Domain {
+	var matched []apiv1alpha1.Domain
+	for _, domain := range owned {
+		first := -1
+		for i, region := range order {
+			if containsString(domain.Spec.Regions, region) {
+				first = i
+				break
+			}
+		}
+		if first == index || (index == 0 && len(domain.Spec.Regions) == 0) {
+			matched = append(matched, domain)
+		}
+	}
+	return matched
+}
+
+// applyVersionWeight shifts weights toward weight percent (0-100) on targetVersion, taking it
+// from whichever single other version currently carries the remainder. Returns ok=false,
+// leaving weights untouched, if more than 1 version other than targetVersion is present, since there's no principled way to redistribute a step weight
+// across more than 2 versions without extra configuration this API doesn't expose yet.
+func applyVersionWeight(weights map[string]int32, targetVersion string, weight int32) (map[string]int32, bool) {
+	others := make([]string, 0, len(weights))
+	for version := range weights {
+		if version != targetVersion {
+			others = append(others, version)
+		}
+	}
+	if len(others) > 1 {
+		return weights, false
+	}
+
+	result := make(map[string]int32, len(weights)+1)
+	for version, w := range weights {
+		result[version] = w
+	}
+	result[targetVersion] = weight
+	if len(others) == 1 {
+		result[others[0]] = 100 - weight
+	}
+	if weight == 0 {
+		delete(result, targetVersion)
+	}
+	if len(others) == 1 && result[others[0]] == 0 {
+		delete(result, others[0])
+	}
+	return result, true
+}
+
+func weightsEqual(a, b map[string]int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for version, weight := range a {
+		if b[version] != weight {
+			return false
+		}
+	}
+	return true
+}
+
+// stepWeight is the weight (0-100) TargetVersion should carry at the given step of strategy.
+func stepWeight(strategy apiv1alpha1.RolloutStrategy, step int32) int32 {
+	if strategy.Type == apiv1alpha1.RolloutStrategyCanary && int(step) < len(strategy.Steps) {
+		return strategy.Steps[step].Weight
+	}
+	return 100
+}
+
+// regionReadiness aggregates pkg/readiness over every Deployment labeled with one of domains'
+// Spec.Id and targetVersion, the same Helm 3-style readiness gate VimanaReconciler.
+// aggregateAvailability uses for a whole Vimana. Unlike that aggregator, an empty result per
+// domain is reported as an explicit not-ready Entry rather than left out of the Report: Report
+// is vacuously Ready when empty (see pkg/readiness), and ComponentReconciler creating the
+// target-version Deployment is asynchronous with this reconcile shifting the weight toward it,
+// so "no Deployment found yet" must not read as "nothing to wait for."
+func regionReadiness(ctx context.Context, c client.Client, namespace string, domains []apiv1alpha1.Domain, targetVersion string) (readiness.Report, error) {
+	logger := log.FromContext(ctx)
+
+	var report readiness.Report
+	for _, domain := range domains {
+		deployments := &appsv1.DeploymentList{}
+		err := c.List(ctx, deployments, client.InNamespace(namespace), client.MatchingLabels{
+			labelDomainKey:  domain.Spec.Id,
+			labelVersionKey: targetVersion,
+		})
+		if err != nil {
+			logger.Error(err, "Failed to list Deployments for readiness check", "namespace", namespace, "domain", domain.Spec.Id)
+			return readiness.Report{}, err
+		}
+		if len(deployments.Items) == 0 {
+			report.Entries = append(report.Entries, readiness.Entry{
+				Kind: "Deployment", Name: fmt.Sprintf("domain=%s,version=%s", domain.Spec.Id, targetVersion),
+				Readiness: readiness.Readiness{Reason: "DeploymentNotFound", Message: "No Deployment for the target version exists yet"},
+			})
+			continue
+		}
+		for i := range deployments.Items {
+			report.Entries = append(report.Entries, readiness.Check(&deployments.Items[i]))
+		}
+	}
+	return report, nil
+}
+
+func pauseElapsed(startedAt *metav1.Time, pause *metav1.Duration) bool {
+	if pause == nil {
+		return true
+	}
+	return durationElapsed(startedAt, pause.Duration)
+}
+
+func timeoutElapsed(startedAt *metav1.Time, timeout *metav1.Duration) bool {
+	d := defaultRolloutTimeout
+	if timeout != nil {
+		d = timeout.Duration
+	}
+	return durationElapsed(startedAt, d)
+}
+
+func durationElapsed(startedAt *metav1.Time, d time.Duration) bool {
+	if startedAt == nil {
+		return true
+	}
+	return time.Since(startedAt.Time) >= d
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+var _ = Describe("VimanaRollout Controller", func() {
+	Context("When reconciling a resource", func() {
+		const namespace = "default"
+		const resourceName = "test-vimana-rollout"
+		const vimanaName = "test-rollout-vimana"
+		const domainId = "test-rollout-domain"
+		const serverId = "test-rollout-server"
+
+		ctx := context.Background()
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+		BeforeEach(func() {
+			domain := &apiv1alpha1.Domain{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: domainId, Namespace: namespace}, domain); err != nil && errors.IsNotFound(err) {
+				Expect(k8sClient.Create(ctx, &apiv1alpha1.Domain{
+					ObjectMeta: metav1.ObjectMeta{Name: domainId, Namespace: namespace},
+					Spec:       apiv1alpha1.DomainSpec{Id: domainId, Vimana: vimanaName},
+				})).To(Succeed())
+			}
+
+			server := &apiv1alpha1.Server{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: serverId, Namespace: namespace}, server); err != nil && errors.IsNotFound(err) {
+				Expect(k8sClient.Create(ctx, &apiv1alpha1.Server{
+					ObjectMeta: metav1.ObjectMeta{Name: serverId, Namespace: namespace, Labels: map[string]string{labelDomainKey: domainId}},
+					Spec: apiv1alpha1.ServerSpec{
+						Id:             serverId,
+						Domain:         domainId,
+						VersionWeights: map[string]int32{"v1": 100},
+					},
+				})).To(Succeed())
+			}
+
+			rollout := &apiv1alpha1.VimanaRollout{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, rollout); err != nil && errors.IsNotFound(err) {
+				Expect(k8sClient.Create(ctx, &apiv1alpha1.VimanaRollout{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace},
+					Spec: apiv1alpha1.VimanaRolloutSpec{
+						VimanaRef:     corev1.LocalObjectReference{Name: vimanaName},
+						TargetVersion: "v2",
+					},
+				})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			rollout := &apiv1alpha1.VimanaRollout{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, rollout)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, rollout)).To(Succeed())
+
+			server := &apiv1alpha1.Server{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serverId, Namespace: namespace}, server)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+
+			domain := &apiv1alpha1.Domain{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: domainId, Namespace: namespace}, domain)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, domain)).To(Succeed())
+		})
+
+		It("should shift VersionWeights to the target version and report Complete with AllAtOnce", func() {
+			controllerReconciler := &VimanaRolloutReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			// First reconcile starts the rollout at its first (only) region.
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Second reconcile applies the weight, but the target-version Deployment the Component
+			// controller will eventually create doesn't exist yet, so the rollout must wait rather
+			// than complete against an empty readiness Report.
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			server := &apiv1alpha1.Server{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serverId, Namespace: namespace}, server)).To(Succeed())
+			Expect(server.Spec.VersionWeights).To(Equal(map[string]int32{"v2": 100}))
+
+			rollout := &apiv1alpha1.VimanaRollout{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, rollout)).To(Succeed())
+			progressing := meta.FindStatusCondition(rollout.Status.Conditions, "RolloutProgressing")
+			Expect(progressing).NotTo(BeNil())
+			Expect(progressing.Status).To(Equal(metav1.ConditionTrue))
+			Expect(progressing.Reason).To(Equal("Waiting"))
+			Expect(rollout.Status.CurrentRegion).To(BeEmpty(), "Spec.Order is unset, so the only region is the empty-string placeholder")
+
+			By("creating the target-version Deployment and reporting it Available")
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-rollout-deployment",
+					Namespace: namespace,
+					Labels:    map[string]string{labelDomainKey: domainId, labelVersionKey: "v2"},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(int32(1)),
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test-rollout-deployment"}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test-rollout-deployment"}},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "busybox"}}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			deployment.Status = appsv1.DeploymentStatus{
+				ObservedGeneration: deployment.Generation,
+				UpdatedReplicas:    1,
+				Conditions:         []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}},
+			}
+			Expect(k8sClient.Status().Update(ctx, deployment)).To(Succeed())
+
+			// Third reconcile finds the Deployment Ready and, with no other regions left, completes.
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, rollout)).To(Succeed())
+			progressing = meta.FindStatusCondition(rollout.Status.Conditions, "RolloutProgressing")
+			Expect(progressing).NotTo(BeNil())
+			Expect(progressing.Status).To(Equal(metav1.ConditionFalse))
+			Expect(progressing.Reason).To(Equal("Complete"))
+			Expect(rollout.Status.CurrentRegion).To(BeEmpty())
+
+			Expect(k8sClient.Delete(ctx, deployment)).To(Succeed())
+		})
+
+		It("should report Waiting, not Complete, for a region with no target-version Deployment yet", func() {
+			controllerReconciler := &VimanaRolloutReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			rollout := &apiv1alpha1.VimanaRollout{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, rollout)).To(Succeed())
+			progressing := meta.FindStatusCondition(rollout.Status.Conditions, "RolloutProgressing")
+			Expect(progressing).NotTo(BeNil())
+			Expect(progressing.Status).To(Equal(metav1.ConditionTrue))
+			Expect(progressing.Reason).To(Equal("Waiting"))
+		})
+
+		It("should skip Servers already running more than 1 non-target version", func() {
+			server := &apiv1alpha1.Server{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serverId, Namespace: namespace}, server)).To(Succeed())
+			server.Spec.VersionWeights = map[string]int32{"v1": 50, "v0": 50}
+			Expect(k8sClient.Update(ctx, server)).To(Succeed())
+
+			controllerReconciler := &VimanaRolloutReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serverId, Namespace: namespace}, server)).To(Succeed())
+			Expect(server.Spec.VersionWeights).To(Equal(map[string]int32{"v1": 50, "v0": 50}))
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+)
+
+// cloudDnsProvider publishes records to Google Cloud DNS, using a geo-location routing policy
+// for records with a Region and a simple routing policy otherwise.
+type cloudDnsProvider struct {
+	project            string
+	serviceAccountJson string
+}
+
+func newCloudDnsProvider(credentials Credentials) (Provider, error) {
+	project, ok := credentials["project"]
+	if !ok {
+		return nil, fmt.Errorf("clouddns: credentials secret is missing \"project\"")
+	}
+	serviceAccountJson, ok := credentials["service_account_json"]
+	if !ok {
+		return nil, fmt.Errorf("clouddns: credentials secret is missing \"service_account_json\"")
+	}
+	return &cloudDnsProvider{project: string(project), serviceAccountJson: string(serviceAccountJson)}, nil
+}
+
+func (p *cloudDnsProvider) Upsert(ctx context.Context, zone string, record Record) error {
+	// TODO: Call dns.ResourceRecordSets.Patch with a RoutingPolicy.GeoPolicy item when
+	//   record.Region is set, or a plain rrset otherwise.
+	return fmt.Errorf("clouddns: Upsert not yet implemented")
+}
+
+func (p *cloudDnsProvider) Delete(ctx context.Context, zone string, record Record) error {
+	// TODO: Call dns.ResourceRecordSets.Delete.
+	return fmt.Errorf("clouddns: Delete not yet implemented")
+}
@@ -0,0 +1,186 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const cloudflareApiBase = "https://api.cloudflare.com/client/v4"
+
+// cloudflareProvider publishes records to Cloudflare DNS. Cloudflare has no native
+// latency/geo routing policy, so records with a Region are published as distinct records
+// distinguished only by SetIdentifier, relying on Cloudflare's own load balancing product
+// (configured out of band) to steer traffic between them.
+//
+// Cloudflare's DNS record API has no notion of a single record carrying multiple targets, so
+// 1 Record with N Targets becomes N Cloudflare records, 1 per target, all tagged with the same
+// cloudflareRecordComment so Upsert/Delete can tell them apart from any other record sharing the
+// same name/type (e.g. a sibling region's records for the same hostname).
+type cloudflareProvider struct {
+	apiToken string
+	client   *http.Client
+}
+
+func newCloudflareProvider(credentials Credentials) (Provider, error) {
+	apiToken, ok := credentials["api_token"]
+	if !ok {
+		return nil, fmt.Errorf("cloudflare: credentials secret is missing \"api_token\"")
+	}
+	return &cloudflareProvider{apiToken: string(apiToken), client: http.DefaultClient}, nil
+}
+
+// cloudflareDnsRecord is the subset of Cloudflare's DNS record object this provider reads/writes.
+type cloudflareDnsRecord struct {
+	Id      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	Comment string `json:"comment,omitempty"`
+	TTL     int    `json:"ttl"`
+	Proxied bool   `json:"proxied"`
+}
+
+type cloudflareResponse struct {
+	Success bool                 `json:"success"`
+	Errors  []cloudflareApiError `json:"errors"`
+	Result  json.RawMessage      `json:"result"`
+}
+
+type cloudflareApiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (p *cloudflareProvider) Upsert(ctx context.Context, zone string, record Record) error {
+	existing, err := p.listRecords(ctx, zone, record)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(record.Targets))
+	for _, target := range record.Targets {
+		wanted[target] = true
+	}
+
+	byContent := make(map[string]cloudflareDnsRecord, len(existing))
+	for _, r := range existing {
+		byContent[r.Content] = r
+	}
+
+	for _, target := range record.Targets {
+		if _, ok := byContent[target]; ok {
+			continue
+		}
+		body := cloudflareDnsRecord{
+			Type:    record.Type,
+			Name:    record.Name,
+			Content: target,
+			Comment: cloudflareRecordComment(record.SetIdentifier),
+			TTL:     1,
+			Proxied: false,
+		}
+		if err := p.call(ctx, http.MethodPost, fmt.Sprintf("/zones/%s/dns_records", zone), body, nil); err != nil {
+			return fmt.Errorf("cloudflare: failed to create record %q -> %q: %w", record.Name, target, err)
+		}
+	}
+
+	for content, r := range byContent {
+		if wanted[content] {
+			continue
+		}
+		if err := p.call(ctx, http.MethodDelete, fmt.Sprintf("/zones/%s/dns_records/%s", zone, r.Id), nil, nil); err != nil {
+			return fmt.Errorf("cloudflare: failed to delete stale record %q -> %q: %w", record.Name, content, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *cloudflareProvider) Delete(ctx context.Context, zone string, record Record) error {
+	existing, err := p.listRecords(ctx, zone, record)
+	if err != nil {
+		return err
+	}
+	for _, r := range existing {
+		if err := p.call(ctx, http.MethodDelete, fmt.Sprintf("/zones/%s/dns_records/%s", zone, r.Id), nil, nil); err != nil {
+			return fmt.Errorf("cloudflare: failed to delete record %q -> %q: %w", record.Name, r.Content, err)
+		}
+	}
+	return nil
+}
+
+// listRecords returns the Cloudflare DNS records in zone that this provider previously
+// published for record.Name/record.Type/record.SetIdentifier.
+func (p *cloudflareProvider) listRecords(ctx context.Context, zone string, record Record) ([]cloudflareDnsRecord, error) {
+	query := url.Values{"type": {record.Type}, "name": {record.Name}}
+	var page []cloudflareDnsRecord
+	if err := p.call(ctx, http.MethodGet, fmt.Sprintf("/zones/%s/dns_records?%s", zone, query.Encode()), nil, &page); err != nil {
+		return nil, fmt.Errorf("cloudflare: failed to list records for %q: %w", record.Name, err)
+	}
+
+	comment := cloudflareRecordComment(record.SetIdentifier)
+	matched := make([]cloudflareDnsRecord, 0, len(page))
+	for _, r := range page {
+		if r.Comment == comment {
+			matched = append(matched, r)
+		}
+	}
+	return matched, nil
+}
+
+// call issues a Cloudflare API v4 request, JSON-encoding body (if non-nil) as the request
+// payload and decoding the response's "result" into out (if non-nil).
+func (p *cloudflareProvider) call(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, cloudflareApiBase+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var decoded cloudflareResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return fmt.Errorf("failed to decode response (status %d): %w", resp.StatusCode, err)
+	}
+	if !decoded.Success {
+		if len(decoded.Errors) > 0 {
+			return fmt.Errorf("status %d: %s (code %d)", resp.StatusCode, decoded.Errors[0].Message, decoded.Errors[0].Code)
+		}
+		return fmt.Errorf("status %d: request was not successful", resp.StatusCode)
+	}
+	if out != nil && len(decoded.Result) > 0 {
+		if err := json.Unmarshal(decoded.Result, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloudflareRecordComment tags every record this provider creates for 1 logical Record with its
+// SetIdentifier, so records for the same Name/Type but a different region (or a different
+// Domain's canonical hostname happening to share a Name) aren't mistaken for each other.
+func cloudflareRecordComment(setIdentifier string) string {
+	return fmt.Sprintf("vimana.host/set-identifier=%s", setIdentifier)
+}
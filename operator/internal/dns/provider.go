@@ -0,0 +1,47 @@
+// Package dns provides a pluggable client for publishing the weighted/latency/geo and failover
+// records that the Domain DNS subsystem (modeled on Kuadrant's DNSPolicy) computes for a
+// Domain's canonical hostname and aliases.
+package dns
+
+import (
+	"context"
+	"fmt"
+)
+
+// Record is 1 desired DNS record to publish for a domain hostname.
+// Region selects a latency/geo routing policy; a Record with no Region is a simple record,
+// used for failover targets where no regional weighting applies.
+type Record struct {
+	Name          string
+	Type          string
+	Targets       []string
+	SetIdentifier string
+	Region        string
+}
+
+// Provider is a pluggable DNS API client. Implementations exist for Route 53, Cloud DNS, and
+// Cloudflare, selected by DnsSpec.Provider.
+type Provider interface {
+	// Upsert creates or updates record in zone.
+	Upsert(ctx context.Context, zone string, record Record) error
+
+	// Delete removes the record identified by name/type/setIdentifier from zone, if present.
+	Delete(ctx context.Context, zone string, record Record) error
+}
+
+// Credentials holds the provider-specific secret material read out of a DnsSpec.SecretRef Secret.
+type Credentials map[string][]byte
+
+// New returns the Provider implementation for the given DNS provider kind.
+func New(provider string, credentials Credentials) (Provider, error) {
+	switch provider {
+	case "Route53":
+		return newRoute53Provider(credentials)
+	case "CloudDNS":
+		return newCloudDnsProvider(credentials)
+	case "Cloudflare":
+		return newCloudflareProvider(credentials)
+	default:
+		return nil, fmt.Errorf("dns: unknown provider %q", provider)
+	}
+}
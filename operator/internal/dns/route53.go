@@ -0,0 +1,36 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+)
+
+// route53Provider publishes records to AWS Route 53, using a latency routing policy for
+// records with a Region and a simple routing policy otherwise.
+type route53Provider struct {
+	accessKeyId     string
+	secretAccessKey string
+}
+
+func newRoute53Provider(credentials Credentials) (Provider, error) {
+	accessKeyId, ok := credentials["access_key_id"]
+	if !ok {
+		return nil, fmt.Errorf("route53: credentials secret is missing \"access_key_id\"")
+	}
+	secretAccessKey, ok := credentials["secret_access_key"]
+	if !ok {
+		return nil, fmt.Errorf("route53: credentials secret is missing \"secret_access_key\"")
+	}
+	return &route53Provider{accessKeyId: string(accessKeyId), secretAccessKey: string(secretAccessKey)}, nil
+}
+
+func (p *route53Provider) Upsert(ctx context.Context, zone string, record Record) error {
+	// TODO: Call route53.ChangeResourceRecordSets with action UPSERT, using a Latency routing
+	//   policy (SetIdentifier + Region) when record.Region is set, or a plain record otherwise.
+	return fmt.Errorf("route53: Upsert not yet implemented")
+}
+
+func (p *route53Provider) Delete(ctx context.Context, zone string, record Record) error {
+	// TODO: Call route53.ChangeResourceRecordSets with action DELETE.
+	return fmt.Errorf("route53: Delete not yet implemented")
+}
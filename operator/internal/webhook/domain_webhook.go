@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// Field indexes maintained on Domain so that hostname-collision checks don't require
+// listing every Domain in the cluster on every admission request.
+const (
+	indexDomainId    = ".spec.id"
+	indexDomainAlias = ".spec.aliases"
+)
+
+// DomainWebhook implements the validating webhook for Domain.
+type DomainWebhook struct {
+	client.Client
+}
+
+// SetupWebhookWithManager registers the field indexes this webhook relies on, then the
+// validating webhook itself, with the manager.
+func (w *DomainWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w.Client = mgr.GetClient()
+
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(context.Background(), &apiv1alpha1.Domain{}, indexDomainId, func(obj client.Object) []string {
+		return []string{obj.(*apiv1alpha1.Domain).Spec.Id}
+	}); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(context.Background(), &apiv1alpha1.Domain{}, indexDomainAlias, func(obj client.Object) []string {
+		return obj.(*apiv1alpha1.Domain).Spec.Aliases
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&apiv1alpha1.Domain{}).
+		WithValidator(w).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-api-vimana-host-v1alpha1-domain,mutating=false,failurePolicy=fail,sideEffects=None,groups=api.vimana.host,resources=domains,verbs=create;update,versions=v1alpha1,name=vdomain.kb.io,admissionReviewVersions=v1
+
+// ValidateCreate rejects a Domain whose primary id or any alias collides with another
+// Domain's primary id or alias, anywhere in the cluster.
+func (w *DomainWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	domain := obj.(*apiv1alpha1.Domain)
+	return nil, w.checkNoCollisions(ctx, domain)
+}
+
+// ValidateUpdate re-runs the same collision check, in case Spec.Id or Spec.Aliases changed.
+func (w *DomainWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	domain := newObj.(*apiv1alpha1.Domain)
+	return nil, w.checkNoCollisions(ctx, domain)
+}
+
+// ValidateDelete allows every deletion.
+func (w *DomainWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// checkNoCollisions looks up, for every hostname domain would claim (its primary id and its
+// aliases), whether some other Domain already claims it as either a primary id or an alias.
+func (w *DomainWebhook) checkNoCollisions(ctx context.Context, domain *apiv1alpha1.Domain) error {
+	hostnames := append([]string{domain.Spec.Id}, domain.Spec.Aliases...)
+	for _, hostname := range hostnames {
+		if owner, ok, err := w.claimedBy(ctx, indexDomainId, hostname, domain); err != nil {
+			return err
+		} else if ok {
+			return collisionError(domain, hostname, owner, "primary id")
+		}
+		if owner, ok, err := w.claimedBy(ctx, indexDomainAlias, hostname, domain); err != nil {
+			return err
+		} else if ok {
+			return collisionError(domain, hostname, owner, "alias")
+		}
+	}
+	return nil
+}
+
+// claimedBy reports whether some Domain other than self already has the given indexed field
+// set to value, and if so, which one.
+func (w *DomainWebhook) claimedBy(ctx context.Context, field, value string, self *apiv1alpha1.Domain) (string, bool, error) {
+	matches := &apiv1alpha1.DomainList{}
+	if err := w.List(ctx, matches, client.MatchingFields{field: value}); err != nil {
+		return "", false, err
+	}
+	for _, match := range matches.Items {
+		if match.Namespace == self.Namespace && match.Name == self.Name {
+			continue
+		}
+		return fmt.Sprintf("%s/%s", match.Namespace, match.Name), true, nil
+	}
+	return "", false, nil
+}
+
+func collisionError(domain *apiv1alpha1.Domain, hostname, owner, kind string) error {
+	return apierrors.NewForbidden(
+		apiv1alpha1.GroupVersion.WithResource("domains").GroupResource(),
+		domain.Name,
+		fmt.Errorf("%q is already claimed as a %s by Domain %q", hostname, kind, owner),
+	)
+}
+
+var _ webhook.CustomValidator = &DomainWebhook{}
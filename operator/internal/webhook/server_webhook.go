@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// indexServerDomain is a field index maintained on Server so that the per-domain collision
+// checks below don't require listing every Server in the cluster on every admission request.
+const indexServerDomain = ".spec.domain"
+
+// reflectionServiceNames are the gRPC reflection services implicitly served by a Server with
+// Spec.Reflection set; see ServerSpec.Reflection.
+var reflectionServiceNames = []string{
+	"grpc.reflection.v1.ServerReflection",
+	"grpc.reflection.v1alpha1.ServerReflection",
+}
+
+// ServerWebhook implements the validating webhook for Server.
+type ServerWebhook struct {
+	client.Client
+}
+
+// SetupWebhookWithManager registers the field index this webhook relies on, then the
+// validating webhook itself, with the manager.
+func (w *ServerWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w.Client = mgr.GetClient()
+
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(context.Background(), &apiv1alpha1.Server{}, indexServerDomain, func(obj client.Object) []string {
+		return []string{obj.(*apiv1alpha1.Server).Spec.Domain}
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&apiv1alpha1.Server{}).
+		WithValidator(w).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-api-vimana-host-v1alpha1-server,mutating=false,failurePolicy=fail,sideEffects=None,groups=api.vimana.host,resources=servers,verbs=create;update,versions=v1alpha1,name=vserver.kb.io,admissionReviewVersions=v1
+
+// ValidateCreate rejects a Server whose Features are malformed (see checkFeatureFlagsWellFormed)
+// or that conflicts with a sibling Server in the same domain (see checkNoDomainConflicts).
+func (w *ServerWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	server := obj.(*apiv1alpha1.Server)
+	if err := checkFeatureFlagsWellFormed(server); err != nil {
+		return nil, err
+	}
+	return nil, w.checkNoDomainConflicts(ctx, server)
+}
+
+// ValidateUpdate re-runs the same checks, in case Spec.Features, Spec.Id, Spec.Services,
+// Spec.Reflection, or Spec.VersionWeights changed.
+func (w *ServerWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	server := newObj.(*apiv1alpha1.Server)
+	if err := checkFeatureFlagsWellFormed(server); err != nil {
+		return nil, err
+	}
+	return nil, w.checkNoDomainConflicts(ctx, server)
+}
+
+// ValidateDelete allows every deletion.
+func (w *ServerWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// checkNoDomainConflicts rejects server if, within its own Spec.Domain: its Spec.Id collides
+// with another Server's; any of its claimed services (Spec.Services, plus the 2 reflection
+// services if Spec.Reflection is set) collides with another Server's; or its VersionWeights is
+// empty. This is a best-effort, cached-client check; DomainReconciler.updateDomainConflictStatus
+// is the authoritative second line of defense against 2 Creates racing past it concurrently.
+func (w *ServerWebhook) checkNoDomainConflicts(ctx context.Context, server *apiv1alpha1.Server) error {
+	var errs field.ErrorList
+	if len(server.Spec.VersionWeights) == 0 {
+		errs = append(errs, field.Required(field.NewPath("spec", "versionWeights"), "must specify at least 1 version weight"))
+	}
+
+	siblings := &apiv1alpha1.ServerList{}
+	if err := w.List(ctx, siblings, client.InNamespace(server.Namespace), client.MatchingFields{indexServerDomain: server.Spec.Domain}); err != nil {
+		return err
+	}
+
+	claimedServices := claimedServices(server)
+	for _, sibling := range siblings.Items {
+		if sibling.Namespace == server.Namespace && sibling.Name == server.Name {
+			continue
+		}
+		if sibling.Spec.Id == server.Spec.Id {
+			errs = append(errs, field.Duplicate(field.NewPath("spec", "id"), server.Spec.Id))
+		}
+		for _, service := range claimedServices {
+			if siblingClaims(sibling, service) {
+				errs = append(errs, field.Duplicate(field.NewPath("spec", "services"), service))
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(apiv1alpha1.GroupVersion.WithKind("Server").GroupKind(), server.Name, errs)
+}
+
+// claimedServices is server.Spec.Services, plus the reflection services it implicitly serves
+// if Spec.Reflection is set.
+func claimedServices(server *apiv1alpha1.Server) []string {
+	if !server.Spec.Reflection {
+		return server.Spec.Services
+	}
+	return append(append([]string{}, server.Spec.Services...), reflectionServiceNames...)
+}
+
+// siblingClaims reports whether sibling claims service, per claimedServices.
+func siblingClaims(sibling apiv1alpha1.Server, service string) bool {
+	for _, claimed := range claimedServices(&sibling) {
+		if claimed == service {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFeatureFlagsWellFormed rejects any FeatureFlag in server.Spec.Features that:
+//   - has no cases;
+//   - doesn't set exactly 1 of Boolean/String/Number on every case, and the same 1 throughout;
+//   - has a final case with conditions (it must be the unconditional default); or
+//   - has conditions anywhere but the final case that are empty (ambiguous with "it's the
+//     default"), or a condition group with no leaf conditions.
+func checkFeatureFlagsWellFormed(server *apiv1alpha1.Server) error {
+	for name, flag := range server.Spec.Features {
+		if err := checkFeatureFlagWellFormed(flag); err != nil {
+			return apierrors.NewInvalid(
+				apiv1alpha1.GroupVersion.WithKind("Server").GroupKind(),
+				server.Name,
+				field.ErrorList{field.Invalid(field.NewPath("spec", "features").Key(name), name, err.Error())},
+			)
+		}
+	}
+	return nil
+}
+
+func checkFeatureFlagWellFormed(flag apiv1alpha1.FeatureFlag) error {
+	if len(flag.Cases) == 0 {
+		return fmt.Errorf("must have at least 1 case")
+	}
+
+	var valueKind string
+	for i, c := range flag.Cases {
+		kind, err := caseValueKind(c)
+		if err != nil {
+			return fmt.Errorf("case %d: %w", i, err)
+		}
+		if valueKind == "" {
+			valueKind = kind
+		} else if kind != valueKind {
+			return fmt.Errorf("case %d sets %q, but earlier cases set %q; every case must use the same value type", i, kind, valueKind)
+		}
+
+		isFinal := i == len(flag.Cases)-1
+		if isFinal && len(c.Conditions) != 0 {
+			return fmt.Errorf("the final case must have no conditions (it's the default)")
+		}
+		if !isFinal && len(c.Conditions) == 0 {
+			return fmt.Errorf("case %d has no conditions, but isn't the final case", i)
+		}
+		for _, group := range c.Conditions {
+			if len(group.All) == 0 {
+				return fmt.Errorf("case %d has a condition group with no leaf conditions", i)
+			}
+			for _, leaf := range group.All {
+				if err := checkConditionWellFormed(leaf); err != nil {
+					return fmt.Errorf("case %d: %w", i, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func caseValueKind(c apiv1alpha1.FeatureFlagCase) (string, error) {
+	switch {
+	case c.Boolean != nil && c.String == nil && c.Number == nil:
+		return "boolean", nil
+	case c.String != nil && c.Boolean == nil && c.Number == nil:
+		return "string", nil
+	case c.Number != nil && c.Boolean == nil && c.String == nil:
+		return "number", nil
+	default:
+		return "", fmt.Errorf("must set exactly 1 of boolean, string, or number")
+	}
+}
+
+func checkConditionWellFormed(condition apiv1alpha1.FeatureFlagCondition) error {
+	if (condition.HasEmail == nil) == (condition.Random == nil) {
+		return fmt.Errorf("condition must set exactly 1 of hasEmail or random")
+	}
+	return nil
+}
+
+var _ webhook.CustomValidator = &ServerWebhook{}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+var _ = Describe("Server Webhook", func() {
+	Context("checkNoDomainConflicts", func() {
+		const domainId = "0123456789abcdef0123456789abcdef"
+		const otherNamespace = "server-webhook-other-ns"
+
+		ctx := context.Background()
+		webhook := &ServerWebhook{Client: k8sClient}
+
+		BeforeEach(func() {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: otherNamespace}}
+			err := k8sClient.Create(ctx, namespace)
+			if err != nil && !apierrors.IsAlreadyExists(err) {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("rejects a Server whose Spec.Id collides with a sibling in the same namespace", func() {
+			existing := &apiv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing-server", Namespace: "default"},
+				Spec: apiv1alpha1.ServerSpec{
+					Id:             "some-id",
+					Domain:         domainId,
+					VersionWeights: map[string]int32{"v1": 1},
+				},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, existing) }()
+
+			conflicting := &apiv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{Name: "conflicting-server", Namespace: "default"},
+				Spec: apiv1alpha1.ServerSpec{
+					Id:             "some-id",
+					Domain:         domainId,
+					VersionWeights: map[string]int32{"v1": 1},
+				},
+			}
+			Expect(webhook.checkNoDomainConflicts(ctx, conflicting)).To(HaveOccurred())
+		})
+
+		It("does not reject a Server whose Spec.Id collides with a same-Domain sibling in a different namespace", func() {
+			existing := &apiv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing-server", Namespace: otherNamespace},
+				Spec: apiv1alpha1.ServerSpec{
+					Id:             "some-id",
+					Domain:         domainId,
+					VersionWeights: map[string]int32{"v1": 1},
+				},
+			}
+			Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+
+			unrelated := &apiv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated-server", Namespace: "default"},
+				Spec: apiv1alpha1.ServerSpec{
+					Id:             "some-id",
+					Domain:         domainId,
+					VersionWeights: map[string]int32{"v1": 1},
+				},
+			}
+			Expect(webhook.checkNoDomainConflicts(ctx, unrelated)).NotTo(HaveOccurred())
+		})
+	})
+})
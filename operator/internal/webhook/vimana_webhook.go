@@ -0,0 +1,83 @@
+// Package webhook holds the admission webhooks that enforce invariants the reconcilers
+// themselves can't, because by the time a reconciler runs, the conflicting resource has
+// already been persisted.
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// labelSingletonKey is stamped by VimanaWebhook's defaulting webhook onto every Vimana, so
+// that the validating webhook's "is there already one in this namespace" check can run as a
+// cheap label-selected List instead of an unfiltered one.
+const labelSingletonKey = "vimana.host/singleton"
+
+// VimanaWebhook implements both the defaulting and validating webhooks for Vimana.
+type VimanaWebhook struct {
+	client.Client
+}
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks with the manager.
+func (w *VimanaWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w.Client = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&apiv1alpha1.Vimana{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-api-vimana-host-v1alpha1-vimana,mutating=true,failurePolicy=fail,sideEffects=None,groups=api.vimana.host,resources=vimanas,verbs=create,versions=v1alpha1,name=mvimana.kb.io,admissionReviewVersions=v1
+
+// Default stamps labelSingletonKey onto every Vimana.
+func (w *VimanaWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	vimana := obj.(*apiv1alpha1.Vimana)
+	if vimana.Labels == nil {
+		vimana.Labels = map[string]string{}
+	}
+	vimana.Labels[labelSingletonKey] = "true"
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-api-vimana-host-v1alpha1-vimana,mutating=false,failurePolicy=fail,sideEffects=None,groups=api.vimana.host,resources=vimanas,verbs=create,versions=v1alpha1,name=vvimana.kb.io,admissionReviewVersions=v1
+
+// ValidateCreate rejects the creation of a second Vimana in a namespace.
+func (w *VimanaWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	vimana := obj.(*apiv1alpha1.Vimana)
+
+	existing := &apiv1alpha1.VimanaList{}
+	if err := w.List(ctx, existing, client.InNamespace(vimana.Namespace), client.MatchingLabels{labelSingletonKey: "true"}); err != nil {
+		return nil, err
+	}
+	if len(existing.Items) > 0 {
+		return nil, apierrors.NewForbidden(
+			apiv1alpha1.GroupVersion.WithResource("vimanas").GroupResource(),
+			vimana.Name,
+			fmt.Errorf("namespace %q already has a Vimana (%q); at most 1 is allowed per namespace", vimana.Namespace, existing.Items[0].Name),
+		)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate allows every update; the singleton invariant can only be violated at creation.
+func (w *VimanaWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete allows every deletion; vimanaFinalizer is what actually gates it.
+func (w *VimanaWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+var _ webhook.CustomDefaulter = &VimanaWebhook{}
+var _ webhook.CustomValidator = &VimanaWebhook{}
@@ -0,0 +1,45 @@
+package features
+
+import (
+	"testing"
+
+	"k8s.io/component-base/featuregate"
+)
+
+func TestDefaults(t *testing.T) {
+	cases := []struct {
+		feature featuregate.Feature
+		want    bool
+	}{
+		{NamespaceScopedRegions, true},
+		{TrafficPolicyV1, true},
+		{MetadataOnlyWatches, true},
+		{HelmStyleReadiness, true},
+		{BackendMTLS, false},
+	}
+	for _, c := range cases {
+		t.Run(string(c.feature), func(t *testing.T) {
+			if got := Enabled(c.feature); got != c.want {
+				t.Errorf("Enabled(%s) = %v, want %v", c.feature, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSetFromFlag(t *testing.T) {
+	gate := featuregate.NewFeatureGate()
+	if err := gate.Add(defaultFeatureGates); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+
+	if err := gate.Set("NamespaceScopedRegions=false,TrafficPolicyV1=true"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	if gate.Enabled(NamespaceScopedRegions) {
+		t.Errorf("NamespaceScopedRegions = true after Set(false)")
+	}
+	if !gate.Enabled(TrafficPolicyV1) {
+		t.Errorf("TrafficPolicyV1 = false after Set(true)")
+	}
+}
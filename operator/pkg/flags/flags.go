@@ -0,0 +1,166 @@
+// Package flags evaluates the FeatureFlag configuration attached to a Server
+// (api/v1alpha1.ServerSpec.Features) against a single request: given a flag name and an
+// EvalInput describing the request, Evaluate walks the flag's cases in order and returns the
+// value of the first one whose conditions match.
+package flags
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// randomModulus is the resolution of the "random" condition's deterministic hash: a "50%" gate
+// matches iff the hash falls below half of it.
+const randomModulus = 10000
+
+// EvalInput carries the per-request data that a flag's conditions are evaluated against.
+type EvalInput struct {
+	// Raw JWT presented with the request (e.g. from an "authorization: Bearer" header), if
+	// any. Only consulted by flags with a hasEmail condition, and only useful if the owning
+	// Server's Auth.Jwks is set.
+	JWT string
+
+	// Stable per-request identifier (e.g. a user or session ID) that a "random" condition's
+	// hash is computed from, so that a given identifier consistently lands on the same side
+	// of the gate across repeated evaluations.
+	RequestID string
+}
+
+// Evaluator evaluates the FeatureFlags configured on a single Server. Construct 1 with
+// NewEvaluator per Server and reuse it across requests: it caches parsed JWKS keys (by URL)
+// behind the scenes, so repeated Evaluate calls don't refetch them on every request.
+type Evaluator struct {
+	flags map[string]apiv1alpha1.FeatureFlag
+	auth  *apiv1alpha1.ServerAuth
+}
+
+// NewEvaluator returns an Evaluator for the given Server feature flag configuration and auth
+// settings (auth may be nil, in which case hasEmail conditions never match).
+func NewEvaluator(featureFlags map[string]apiv1alpha1.FeatureFlag, auth *apiv1alpha1.ServerAuth) *Evaluator {
+	return &Evaluator{flags: featureFlags, auth: auth}
+}
+
+// Evaluate returns the value (a bool, string, or float64, matching whichever of
+// Boolean/String/Number the matching case set) of the named flag for the given request, or an
+// error if the flag doesn't exist, has no matching case, or a condition fails to evaluate (e.g.
+// an unparseable JWT).
+func (e *Evaluator) Evaluate(ctx context.Context, flagName string, input EvalInput) (any, error) {
+	flag, ok := e.flags[flagName]
+	if !ok {
+		return nil, fmt.Errorf("flags: no feature flag named %q", flagName)
+	}
+
+	for _, c := range flag.Cases {
+		matched, err := e.caseMatches(ctx, flagName, c, input)
+		if err != nil {
+			return nil, fmt.Errorf("flags: evaluating %q: %w", flagName, err)
+		}
+		if matched {
+			return caseValue(c), nil
+		}
+	}
+	return nil, fmt.Errorf("flags: %q has no matching case (its final case should be an unconditional default)", flagName)
+}
+
+// caseValue returns whichever of Boolean, String, or Number c set.
+func caseValue(c apiv1alpha1.FeatureFlagCase) any {
+	switch {
+	case c.Boolean != nil:
+		return *c.Boolean
+	case c.String != nil:
+		return *c.String
+	case c.Number != nil:
+		return *c.Number
+	default:
+		return nil
+	}
+}
+
+// caseMatches reports whether c's Conditions match, per EvalInput. No conditions always
+// matches (the default-case shape).
+func (e *Evaluator) caseMatches(ctx context.Context, flagName string, c apiv1alpha1.FeatureFlagCase, input EvalInput) (bool, error) {
+	if len(c.Conditions) == 0 {
+		return true, nil
+	}
+
+	// Top-level conditions are OR-joined: the case matches as soon as 1 group matches.
+	for _, group := range c.Conditions {
+		matched, err := e.groupMatches(ctx, flagName, group, input)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// groupMatches reports whether every leaf condition in group.All matches ("AND-joined"),
+// short-circuiting on the first that doesn't.
+func (e *Evaluator) groupMatches(ctx context.Context, flagName string, group apiv1alpha1.FeatureFlagConditionGroup, input EvalInput) (bool, error) {
+	for _, leaf := range group.All {
+		matched, err := e.leafMatches(ctx, flagName, leaf, input)
+		if err != nil {
+			return false, err
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// leafMatches evaluates a single hasEmail or random condition.
+func (e *Evaluator) leafMatches(ctx context.Context, flagName string, condition apiv1alpha1.FeatureFlagCondition, input EvalInput) (bool, error) {
+	switch {
+	case condition.HasEmail != nil:
+		email, err := e.verifiedEmail(ctx, input.JWT)
+		if err != nil {
+			return false, err
+		}
+		if email == "" {
+			return false, nil
+		}
+		matched, err := path.Match(*condition.HasEmail, email)
+		if err != nil {
+			return false, fmt.Errorf("invalid hasEmail glob %q: %w", *condition.HasEmail, err)
+		}
+		return matched, nil
+
+	case condition.Random != nil:
+		threshold, err := randomThreshold(*condition.Random)
+		if err != nil {
+			return false, err
+		}
+		return requestHash(input.RequestID, flagName) < threshold, nil
+
+	default:
+		return false, fmt.Errorf("condition has neither hasEmail nor random set")
+	}
+}
+
+// randomThreshold parses a "N%" string into the equivalent cutoff against requestHash's
+// [0, randomModulus) range.
+func randomThreshold(percent string) (uint64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(percent), "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid random percentage %q: %w", percent, err)
+	}
+	return uint64(value / 100 * randomModulus), nil
+}
+
+// requestHash deterministically hashes (requestID, flagName) into [0, randomModulus), so that a
+// given request ID consistently lands on the same side of every "random" gate on a flag across
+// evaluations, while still varying independently per flag.
+func requestHash(requestID, flagName string) uint64 {
+	sum := sha256.Sum256([]byte(requestID + "|" + flagName))
+	return binary.BigEndian.Uint64(sum[:8]) % randomModulus
+}
@@ -0,0 +1,134 @@
+package flags
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	apiv1alpha1 "vimana.host/operator/api/v1alpha1"
+)
+
+// always and never are "random" conditions that are guaranteed to match or not match,
+// regardless of the request identifier's hash, so tests don't depend on sha256 internals.
+func always() apiv1alpha1.FeatureFlagCondition { return apiv1alpha1.FeatureFlagCondition{Random: ptr.To("100%")} }
+func never() apiv1alpha1.FeatureFlagCondition  { return apiv1alpha1.FeatureFlagCondition{Random: ptr.To("0%")} }
+
+func group(conditions ...apiv1alpha1.FeatureFlagCondition) apiv1alpha1.FeatureFlagConditionGroup {
+	return apiv1alpha1.FeatureFlagConditionGroup{All: conditions}
+}
+
+func TestEvaluateCaseOrdering(t *testing.T) {
+	evaluator := NewEvaluator(map[string]apiv1alpha1.FeatureFlag{
+		"flag": {Cases: []apiv1alpha1.FeatureFlagCase{
+			{String: ptr.To("first"), Conditions: []apiv1alpha1.FeatureFlagConditionGroup{group(always())}},
+			{String: ptr.To("second"), Conditions: []apiv1alpha1.FeatureFlagConditionGroup{group(always())}},
+			{String: ptr.To("default")},
+		}},
+	}, nil)
+
+	got, err := evaluator.Evaluate(context.Background(), "flag", EvalInput{RequestID: "user-1"})
+	if err != nil {
+		t.Fatalf("Evaluate() failed: %v", err)
+	}
+	if got != "first" {
+		t.Errorf("Evaluate() = %v, want %q (the first matching case)", got, "first")
+	}
+}
+
+func TestEvaluateNestedAndOr(t *testing.T) {
+	cases := []struct {
+		name       string
+		conditions []apiv1alpha1.FeatureFlagConditionGroup
+		want       bool
+	}{
+		{"or of 2 matching groups", []apiv1alpha1.FeatureFlagConditionGroup{group(always()), group(always())}, true},
+		{"or with 1 matching group", []apiv1alpha1.FeatureFlagConditionGroup{group(never()), group(always())}, true},
+		{"or with no matching groups", []apiv1alpha1.FeatureFlagConditionGroup{group(never()), group(never())}, false},
+		{"and short-circuits on the first false", []apiv1alpha1.FeatureFlagConditionGroup{group(never(), always())}, false},
+		{"and requires every leaf", []apiv1alpha1.FeatureFlagConditionGroup{group(always(), always())}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evaluator := NewEvaluator(map[string]apiv1alpha1.FeatureFlag{
+				"flag": {Cases: []apiv1alpha1.FeatureFlagCase{
+					{Boolean: ptr.To(true), Conditions: c.conditions},
+					{Boolean: ptr.To(false)},
+				}},
+			}, nil)
+
+			got, err := evaluator.Evaluate(context.Background(), "flag", EvalInput{RequestID: "user-1"})
+			if err != nil {
+				t.Fatalf("Evaluate() failed: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("Evaluate() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateDefaultCaseFallback(t *testing.T) {
+	evaluator := NewEvaluator(map[string]apiv1alpha1.FeatureFlag{
+		"flag": {Cases: []apiv1alpha1.FeatureFlagCase{
+			{Boolean: ptr.To(true), Conditions: []apiv1alpha1.FeatureFlagConditionGroup{group(never())}},
+			{Boolean: ptr.To(false)},
+		}},
+	}, nil)
+
+	got, err := evaluator.Evaluate(context.Background(), "flag", EvalInput{RequestID: "user-1"})
+	if err != nil {
+		t.Fatalf("Evaluate() failed: %v", err)
+	}
+	if got != false {
+		t.Errorf("Evaluate() = %v, want the default case's value (false)", got)
+	}
+}
+
+func TestEvaluateNoMatchingCase(t *testing.T) {
+	evaluator := NewEvaluator(map[string]apiv1alpha1.FeatureFlag{
+		"flag": {Cases: []apiv1alpha1.FeatureFlagCase{
+			{Boolean: ptr.To(true), Conditions: []apiv1alpha1.FeatureFlagConditionGroup{group(never())}},
+		}},
+	}, nil)
+
+	if _, err := evaluator.Evaluate(context.Background(), "flag", EvalInput{RequestID: "user-1"}); err == nil {
+		t.Error("Evaluate() succeeded, want an error for a flag with no default case")
+	}
+}
+
+func TestEvaluateUnknownFlag(t *testing.T) {
+	evaluator := NewEvaluator(nil, nil)
+	if _, err := evaluator.Evaluate(context.Background(), "missing", EvalInput{}); err == nil {
+		t.Error("Evaluate() succeeded, want an error for an undefined flag")
+	}
+}
+
+func TestRandomThreshold(t *testing.T) {
+	cases := []struct {
+		percent string
+		want    uint64
+	}{
+		{"50%", 5000},
+		{"0%", 0},
+		{"100%", 10000},
+	}
+	for _, c := range cases {
+		got, err := randomThreshold(c.percent)
+		if err != nil {
+			t.Fatalf("randomThreshold(%q) failed: %v", c.percent, err)
+		}
+		if got != c.want {
+			t.Errorf("randomThreshold(%q) = %d, want %d", c.percent, got, c.want)
+		}
+	}
+}
+
+func TestRequestHashIsDeterministic(t *testing.T) {
+	first := requestHash("user-1", "flag")
+	second := requestHash("user-1", "flag")
+	if first != second {
+		t.Errorf("requestHash() is not deterministic: got %d then %d", first, second)
+	}
+}
@@ -0,0 +1,152 @@
+package flags
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval bounds how long a cached key set is trusted before it's refetched, so a
+// rotated signing key is picked up without requiring every backend to restart.
+const jwksRefreshInterval = 10 * time.Minute
+
+// sharedJWKSCache is process-wide: Servers commonly share an auth provider, so every Evaluator
+// fetches (and refreshes) a given JWKS URL's keys once rather than once per Server.
+var sharedJWKSCache = &jwksCache{byURL: map[string]*cachedKeySet{}}
+
+type jwksCache struct {
+	mu    sync.Mutex
+	byURL map[string]*cachedKeySet
+}
+
+type cachedKeySet struct {
+	keys      map[string]*rsa.PublicKey // keyed by "kid"
+	fetchedAt time.Time
+}
+
+// keyfunc returns a jwt.Keyfunc that resolves a token's "kid" header against the keys of every
+// URL in urls, fetching (and caching) each lazily.
+func (c *jwksCache) keyfunc(ctx context.Context, urls []string) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		for _, url := range urls {
+			keySet, err := c.get(ctx, url)
+			if err != nil {
+				continue
+			}
+			if key, ok := keySet.keys[kid]; ok {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("no JWKS among %v has a key for kid %q", urls, kid)
+	}
+}
+
+// get returns the cached key set for url, refetching it if it's unset or stale. A stale entry
+// is still returned (rather than erroring the request) if the refetch itself fails.
+func (c *jwksCache) get(ctx context.Context, url string) (*cachedKeySet, error) {
+	c.mu.Lock()
+	cached, ok := c.byURL[url]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < jwksRefreshInterval {
+		return cached, nil
+	}
+
+	fetched, err := fetchJWKS(ctx, url)
+	if err != nil {
+		if ok {
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.byURL[url] = fetched
+	c.mu.Unlock()
+	return fetched, nil
+}
+
+// jwkSet is the subset of RFC 7517's JWK Set format this package understands: RSA public keys.
+type jwkSet struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func fetchJWKS(ctx context.Context, url string) (*cachedKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building JWKS request for %q: %w", url, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decoding JWKS %q: %w", url, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" {
+			// Only RSA keys are supported; skip anything else (e.g. EC) rather than fail the
+			// whole set over 1 unsupported key.
+			continue
+		}
+		publicKey, err := rsaPublicKey(key.N, key.E)
+		if err != nil {
+			continue
+		}
+		keys[key.Kid] = publicKey
+	}
+	return &cachedKeySet{keys: keys, fetchedAt: time.Now()}, nil
+}
+
+func rsaPublicKey(modulusB64, exponentB64 string) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(modulusB64)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(exponentB64)
+	if err != nil {
+		return nil, err
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+// verifiedEmail parses and validates rawJWT against e.auth.Jwks, returning its "email" claim
+// iff the token's "email_verified" claim is true. Returns "", nil (not an error) if there's no
+// JWT to check or no JWKS configured to check it against.
+func (e *Evaluator) verifiedEmail(ctx context.Context, rawJWT string) (string, error) {
+	if rawJWT == "" || e.auth == nil || len(e.auth.Jwks) == 0 {
+		return "", nil
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(rawJWT, claims, sharedJWKSCache.keyfunc(ctx, e.auth.Jwks)); err != nil {
+		return "", fmt.Errorf("validating JWT: %w", err)
+	}
+
+	if verified, _ := claims["email_verified"].(bool); !verified {
+		return "", nil
+	}
+	email, _ := claims["email"].(string)
+	return email, nil
+}
@@ -0,0 +1,192 @@
+// Package readiness provides a Helm 3-style resource readiness checker: per-kind predicates that
+// decide whether a resource is actually serving, not just created, so a controller can aggregate
+// its own Available condition from the real state of the children it manages instead of from the
+// reconcile outcome alone.
+package readiness
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Readiness is the outcome of checking a single resource: whether it's actually ready, plus a
+// machine-friendly Reason and a human Message explaining why (or why not).
+type Readiness struct {
+	Ready   bool
+	Reason  string
+	Message string
+}
+
+// Checker reports the Readiness of a resource of some kind. Implementations assume obj has
+// already been fetched; Check never talks to the API server itself.
+type Checker interface {
+	Check(obj client.Object) Readiness
+}
+
+// Entry is one row of a Report: the Readiness of a single named resource.
+type Entry struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Readiness
+}
+
+// Report aggregates the Readiness of every resource a caller checked during one reconcile.
+type Report struct {
+	Entries []Entry
+}
+
+// Ready reports whether every Entry in the Report is Ready. An empty Report is vacuously Ready.
+func (r Report) Ready() bool {
+	for _, entry := range r.Entries {
+		if !entry.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// Message summarizes every not-Ready Entry, suitable for a Condition's Message, e.g.
+// "2 resource(s) not ready: Deployment/foo (RolloutInProgress: 1/3 replicas updated); Service/bar (ClusterIPPending: ...)".
+func (r Report) Message() string {
+	var unready []Entry
+	for _, entry := range r.Entries {
+		if !entry.Ready {
+			unready = append(unready, entry)
+		}
+	}
+	if len(unready) == 0 {
+		return "All resources are ready"
+	}
+	parts := make([]string, len(unready))
+	for i, entry := range unready {
+		parts[i] = fmt.Sprintf("%s/%s (%s: %s)", entry.Kind, entry.Name, entry.Reason, entry.Message)
+	}
+	return fmt.Sprintf("%d resource(s) not ready: %s", len(unready), strings.Join(parts, "; "))
+}
+
+// Check resolves obj's kind to the matching Checker (falling back to presence for kinds with no
+// dedicated predicate) and returns the resulting Entry.
+func Check(obj client.Object) Entry {
+	kind, checker := checkerFor(obj)
+	return Entry{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Readiness: checker.Check(obj),
+	}
+}
+
+// checkerFor resolves the Checker for obj's concrete type. Add a case here to plug in a new kind.
+func checkerFor(obj client.Object) (string, Checker) {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return "Deployment", DeploymentChecker{}
+	case *corev1.Service:
+		return "Service", ServiceChecker{}
+	case *corev1.Pod:
+		return "Pod", PodChecker{}
+	case *apiextensionsv1.CustomResourceDefinition:
+		return "CustomResourceDefinition", CRDChecker{}
+	default:
+		return fmt.Sprintf("%T", obj), PresenceChecker{}
+	}
+}
+
+// DeploymentChecker reports a Deployment Ready once its controller has observed the latest spec,
+// rolled every replica to it, and posted its own Available condition True — the same signal
+// `kubectl rollout status` and Helm 3's readiness checker wait on.
+type DeploymentChecker struct{}
+
+func (DeploymentChecker) Check(obj client.Object) Readiness {
+	deployment := obj.(*appsv1.Deployment)
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return Readiness{Reason: "ObservationPending", Message: "Waiting for the Deployment controller to observe the latest spec"}
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas != desired {
+		return Readiness{Reason: "RolloutInProgress", Message: fmt.Sprintf("%d/%d replicas updated", deployment.Status.UpdatedReplicas, desired)}
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			if condition.Status == corev1.ConditionTrue {
+				return Readiness{Ready: true, Reason: "MinimumReplicasAvailable", Message: "Deployment has the minimum number of replicas available"}
+			}
+			return Readiness{Reason: condition.Reason, Message: condition.Message}
+		}
+	}
+	return Readiness{Reason: "AvailableUnknown", Message: "Deployment has not yet reported an Available condition"}
+}
+
+// ServiceChecker reports a Service Ready once it has been allocated a ClusterIP, or, for a
+// LoadBalancer Service, once the external load balancer has been provisioned.
+type ServiceChecker struct{}
+
+func (ServiceChecker) Check(obj client.Object) Readiness {
+	service := obj.(*corev1.Service)
+
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if len(service.Status.LoadBalancer.Ingress) == 0 {
+			return Readiness{Reason: "LoadBalancerPending", Message: "Waiting for the load balancer to be provisioned"}
+		}
+		return Readiness{Ready: true, Reason: "LoadBalancerProvisioned", Message: "Load balancer ingress has been assigned"}
+	}
+	if service.Spec.ClusterIP == "" {
+		return Readiness{Reason: "ClusterIPPending", Message: "Waiting for a ClusterIP to be allocated"}
+	}
+	return Readiness{Ready: true, Reason: "ClusterIPAllocated", Message: "ClusterIP has been allocated"}
+}
+
+// PodChecker reports a Pod Ready once it has reached phase Running and every container within it
+// reports ready==true.
+type PodChecker struct{}
+
+func (PodChecker) Check(obj client.Object) Readiness {
+	pod := obj.(*corev1.Pod)
+
+	if pod.Status.Phase != corev1.PodRunning {
+		return Readiness{Reason: "NotRunning", Message: fmt.Sprintf("Pod is in phase %s", pod.Status.Phase)}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready {
+			return Readiness{Reason: "ContainerNotReady", Message: fmt.Sprintf("Container %q is not ready", status.Name)}
+		}
+	}
+	return Readiness{Ready: true, Reason: "Running", Message: "Pod is running with all containers ready"}
+}
+
+// CRDChecker reports a CustomResourceDefinition Ready once its Established condition is True,
+// matching Helm 3's own wait for CRDs it installs.
+type CRDChecker struct{}
+
+func (CRDChecker) Check(obj client.Object) Readiness {
+	crd := obj.(*apiextensionsv1.CustomResourceDefinition)
+
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1.Established {
+			if condition.Status == apiextensionsv1.ConditionTrue {
+				return Readiness{Ready: true, Reason: "Established", Message: "CustomResourceDefinition is established"}
+			}
+			return Readiness{Reason: condition.Reason, Message: condition.Message}
+		}
+	}
+	return Readiness{Reason: "EstablishedUnknown", Message: "CustomResourceDefinition has not yet reported an Established condition"}
+}
+
+// PresenceChecker is the fallback for any kind with no dedicated predicate: it reports Ready as
+// soon as the resource exists, on the assumption that the caller only passes in resources it has
+// successfully fetched or listed.
+type PresenceChecker struct{}
+
+func (PresenceChecker) Check(obj client.Object) Readiness {
+	return Readiness{Ready: true, Reason: "Present", Message: "Resource exists"}
+}
@@ -0,0 +1,117 @@
+package readiness
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeploymentChecker(t *testing.T) {
+	cases := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		want       bool
+	}{
+		{
+			name: "observed generation stale",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+			},
+			want: false,
+		},
+		{
+			name: "rollout in progress",
+			deployment: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+				Status: appsv1.DeploymentStatus{UpdatedReplicas: 1},
+			},
+			want: false,
+		},
+		{
+			name: "available",
+			deployment: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+				Status: appsv1.DeploymentStatus{
+					UpdatedReplicas: 1,
+					Conditions: []appsv1.DeploymentCondition{
+						{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := (DeploymentChecker{}).Check(c.deployment).Ready; got != c.want {
+				t.Errorf("Check() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestServiceChecker(t *testing.T) {
+	cases := []struct {
+		name    string
+		service *corev1.Service
+		want    bool
+	}{
+		{
+			name:    "cluster IP pending",
+			service: &corev1.Service{Spec: corev1.ServiceSpec{}},
+			want:    false,
+		},
+		{
+			name:    "cluster IP allocated",
+			service: &corev1.Service{Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.1"}},
+			want:    true,
+		},
+		{
+			name: "load balancer pending",
+			service: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+			},
+			want: false,
+		},
+		{
+			name: "load balancer provisioned",
+			service: &corev1.Service{
+				Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := (ServiceChecker{}).Check(c.service).Ready; got != c.want {
+				t.Errorf("Check() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	report := Report{Entries: []Entry{
+		{Kind: "Deployment", Name: "foo", Readiness: Readiness{Ready: true}},
+		{Kind: "Service", Name: "bar", Readiness: Readiness{Ready: false, Reason: "ClusterIPPending", Message: "waiting"}},
+	}}
+
+	if report.Ready() {
+		t.Errorf("Ready() = true, want false")
+	}
+	want := `1 resource(s) not ready: Service/bar (ClusterIPPending: waiting)`
+	if got := report.Message(); got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+
+	if empty := (Report{}); !empty.Ready() {
+		t.Errorf("Ready() on empty Report = false, want true")
+	}
+}